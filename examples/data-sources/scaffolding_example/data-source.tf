@@ -1,3 +0,0 @@
-data "ubika_example" "example" {
-  configurable_attribute = "some-value"
-}
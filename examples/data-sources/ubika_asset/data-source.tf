@@ -0,0 +1,10 @@
+data "ubika_asset" "example" {
+  metadata = {
+    namespace = "default"
+    name      = "terraform-test-asset"
+  }
+}
+
+output "service_address" {
+  value = data.ubika_asset.example.status.service_address
+}
@@ -0,0 +1,7 @@
+data "ubika_assets" "example" {
+  namespace = "default"
+}
+
+output "service_addresses" {
+  value = { for asset in data.ubika_assets.example.items : asset.metadata.name => asset.status.service_address }
+}
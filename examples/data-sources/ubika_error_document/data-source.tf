@@ -0,0 +1,10 @@
+data "ubika_error_document" "example" {
+  metadata = {
+    namespace = "default"
+    name      = "terraform-test-error-document"
+  }
+}
+
+output "page" {
+  value = data.ubika_error_document.example.spec.page
+}
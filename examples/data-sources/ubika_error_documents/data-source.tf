@@ -0,0 +1,7 @@
+data "ubika_error_documents" "example" {
+  namespace = "default"
+}
+
+output "names" {
+  value = [for item in data.ubika_error_documents.example.items : item.metadata.name]
+}
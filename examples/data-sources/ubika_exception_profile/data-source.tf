@@ -0,0 +1,10 @@
+data "ubika_exception_profile" "example" {
+  metadata = {
+    namespace = "default"
+    name      = "terraform-test-exception-profile"
+  }
+}
+
+output "rules" {
+  value = data.ubika_exception_profile.example.spec.rules
+}
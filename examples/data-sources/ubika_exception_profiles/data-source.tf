@@ -0,0 +1,7 @@
+data "ubika_exception_profiles" "example" {
+  namespace = "default"
+}
+
+output "names" {
+  value = [for item in data.ubika_exception_profiles.example.items : item.metadata.name]
+}
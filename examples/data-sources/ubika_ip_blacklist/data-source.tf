@@ -0,0 +1,10 @@
+data "ubika_ip_blacklist" "example" {
+  metadata = {
+    namespace = "default"
+    name      = "terraform-test-ip-blacklist"
+  }
+}
+
+output "ip_addresses" {
+  value = data.ubika_ip_blacklist.example.spec.ip_addresses
+}
@@ -0,0 +1,7 @@
+data "ubika_ip_blacklists" "example" {
+  namespace = "default"
+}
+
+output "names" {
+  value = [for item in data.ubika_ip_blacklists.example.items : item.metadata.name]
+}
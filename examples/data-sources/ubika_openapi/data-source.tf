@@ -0,0 +1,10 @@
+data "ubika_openapi" "example" {
+  metadata = {
+    namespace = "default"
+    name      = "terraform-test-openapi"
+  }
+}
+
+output "source" {
+  value = data.ubika_openapi.example.spec.source
+}
@@ -0,0 +1,7 @@
+data "ubika_openapis" "example" {
+  namespace = "default"
+}
+
+output "names" {
+  value = [for item in data.ubika_openapis.example.items : item.metadata.name]
+}
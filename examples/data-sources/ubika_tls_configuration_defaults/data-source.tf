@@ -0,0 +1,23 @@
+data "ubika_tls_configuration_defaults" "this" {
+}
+
+variable "wanted_ciphers" {
+  type    = set(string)
+  default = [
+    "ECDHE-ECDSA-AES128-GCM-SHA256",
+    "ECDHE-RSA-AES128-GCM-SHA256",
+    "ECDHE-ECDSA-CHACHA20-POLY1305",
+  ]
+}
+
+resource "ubika_tls_configuration" "hardened" {
+  metadata = {
+    namespace = "default"
+    name      = "hardened"
+  }
+  spec = {
+    protocol_min = "TLS_1_2"
+    protocol_max = "TLS_1_3"
+    ciphers      = setintersection(var.wanted_ciphers, data.ubika_tls_configuration_defaults.this.ciphers_available)
+  }
+}
@@ -0,0 +1,12 @@
+data "ubika_tls_materials" "expiring" {
+  namespace           = "default"
+  issuer              = "Internal CA"
+  expires_within_days = 30
+}
+
+check "certificates_expiration" {
+  assert {
+    condition     = length(data.ubika_tls_materials.expiring.items) == 0
+    error_message = "Certificates expiring within 30 days: ${join(", ", [for m in data.ubika_tls_materials.expiring.items : m.id])}"
+  }
+}
@@ -0,0 +1,10 @@
+data "ubika_workflow" "example" {
+  metadata = {
+    namespace = "default"
+    name      = "terraform-test-workflow"
+  }
+}
+
+output "entrypoint" {
+  value = data.ubika_workflow.example.spec.entrypoint
+}
@@ -0,0 +1,7 @@
+data "ubika_workflows" "example" {
+  namespace = "default"
+}
+
+output "names" {
+  value = [for item in data.ubika_workflows.example.items : item.metadata.name]
+}
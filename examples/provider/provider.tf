@@ -1,3 +1,47 @@
+# Authenticate with the current appsecctl context
+provider "ubika" {}
+
+# Authenticate with another appsecctl context
 provider "ubika" {
-  # example configuration here
+  alias        = "staging"
+  auth_context = "staging"
+}
+
+# Authenticate with credentials, which can also be set with the UBIKA_USERNAME
+# and UBIKA_PASSWORD environment variables
+provider "ubika" {
+  alias    = "ci"
+  username = var.ubika_username
+  password = var.ubika_password
+}
+
+# Authenticate with a service account, which can also be set with the
+# UBIKA_CLIENT_ID and UBIKA_CLIENT_SECRET environment variables
+provider "ubika" {
+  alias         = "service_account"
+  client_id     = "terraform"
+  client_secret = var.ubika_client_secret
+}
+
+# Connect to a self-hosted deployment behind an internal CA, with mutual TLS
+provider "ubika" {
+  alias        = "self_hosted"
+  host         = "ubika.internal.example.com"
+  ca_cert_file = "/etc/ssl/internal-ca.pem"
+  client_cert  = file("client.pem")
+  client_key   = file("client-key.pem")
+}
+
+variable "ubika_username" {
+  type = string
+}
+
+variable "ubika_password" {
+  type      = string
+  sensitive = true
+}
+
+variable "ubika_client_secret" {
+  type      = string
+  sensitive = true
 }
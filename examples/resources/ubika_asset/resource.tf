@@ -17,3 +17,27 @@ resource "ubika_asset" "example" {
     deployment_type = "SAAS"
   }
 }
+
+# Wait for the asset to be up and running before dependent resources are
+# created, e.g. smoke tests
+resource "ubika_asset" "ready" {
+  metadata = {
+    namespace = "default"
+    name      = "terraform-ready-asset"
+  }
+  spec = {
+    hostnames       = ["ready.example.com"]
+    backend_url     = "http://ready.example.com/"
+    deployment_type = "SAAS"
+  }
+
+  wait_for {
+    running_state = "ALIVE"
+    backend       = "OK"
+  }
+
+  timeouts {
+    create = "30m"
+    delete = "10m"
+  }
+}
@@ -0,0 +1,2 @@
+# Exception profiles can be imported by specifying the namespace and the name
+terraform import ubika_exception_profile.example default/terraform-test-exception-profile
@@ -0,0 +1,27 @@
+resource "ubika_exception_profile" "example" {
+  metadata = {
+    namespace = "default"
+    name      = "terraform-test-exception-profile"
+  }
+  spec = {
+    rules = [
+      {
+        name    = "health-check"
+        filters = ["path == '/health'"]
+      },
+    ]
+  }
+}
+
+resource "ubika_asset" "example" {
+  metadata = {
+    namespace = "default"
+    name      = "terraform-test-asset"
+  }
+  spec = {
+    hostnames         = ["terraform.example.com"]
+    backend_url       = "http://terraform.example.com/"
+    deployment_type   = "SAAS"
+    exception_profile = ubika_exception_profile.example.metadata.name
+  }
+}
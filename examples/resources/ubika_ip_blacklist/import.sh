@@ -0,0 +1,2 @@
+# IP blacklists can be imported by specifying the namespace and the name
+terraform import ubika_ip_blacklist.example default/terraform-test-ip-blacklist
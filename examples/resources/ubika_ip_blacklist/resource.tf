@@ -0,0 +1,13 @@
+resource "ubika_ip_blacklist" "example" {
+  metadata = {
+    namespace = "default"
+    name      = "terraform-test-ip-blacklist"
+  }
+  spec = {
+    ip_addresses = [
+      "192.0.2.10",
+      "198.51.100.0/24",
+      "2001:db8::/32",
+    ]
+  }
+}
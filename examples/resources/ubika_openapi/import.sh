@@ -0,0 +1,2 @@
+# OpenAPI specifications can be imported by specifying the namespace and the name
+terraform import ubika_openapi.example default/terraform-test-openapi
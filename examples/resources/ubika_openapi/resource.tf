@@ -0,0 +1,24 @@
+resource "ubika_openapi" "example" {
+  metadata = {
+    namespace = "default"
+    name      = "terraform-test-openapi"
+  }
+  spec = {
+    source_file = "${path.module}/openapi.yaml"
+  }
+}
+
+resource "ubika_asset" "example" {
+  metadata = {
+    namespace = "default"
+    name      = "terraform-test-asset"
+  }
+  spec = {
+    hostnames       = ["terraform.example.com"]
+    backend_url     = "http://terraform.example.com/"
+    deployment_type = "SAAS"
+    api_module = {
+      openapi = ubika_openapi.example.metadata.name
+    }
+  }
+}
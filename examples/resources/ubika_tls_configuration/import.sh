@@ -0,0 +1,2 @@
+# TLS configurations can be imported by specifying the namespace and the name
+terraform import ubika_tls_configuration.example default/terraform-test-tls-configuration
@@ -0,0 +1,14 @@
+resource "ubika_tls_configuration" "example" {
+  metadata = {
+    namespace = "default"
+    name      = "terraform-test-tls-configuration"
+  }
+  spec = {
+    protocol_min = "TLS_1_2"
+    protocol_max = "TLS_1_3"
+    ciphers = [
+      "ECDHE-ECDSA-AES128-GCM-SHA256",
+      "ECDHE-RSA-AES128-GCM-SHA256",
+    ]
+  }
+}
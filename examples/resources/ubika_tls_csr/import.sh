@@ -0,0 +1,2 @@
+# TLS CSRs can be imported by specifying the namespace and the name
+terraform import ubika_tls_csr.example default/terraform-test-tls-csr
@@ -0,0 +1,9 @@
+resource "ubika_tls_csr" "example" {
+  metadata = {
+    namespace = "default"
+    name      = "terraform-test-tls-csr"
+  }
+  spec = {
+    asset = "terraform-test-asset"
+  }
+}
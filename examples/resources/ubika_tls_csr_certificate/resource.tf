@@ -0,0 +1,30 @@
+resource "ubika_tls_csr" "example" {
+  metadata = {
+    namespace = "default"
+    name      = "terraform-test-tls-csr"
+  }
+  spec = {
+    asset = "terraform-test-asset"
+  }
+}
+
+# sign the CSR with a local CA, the private key never leaves the platform
+resource "tls_locally_signed_cert" "example" {
+  cert_request_pem      = ubika_tls_csr.example.spec.csr
+  ca_private_key_pem    = file("${path.module}/ca.key")
+  ca_cert_pem           = file("${path.module}/ca.crt")
+  validity_period_hours = 8760
+  allowed_uses          = ["digital_signature", "server_auth"]
+}
+
+resource "ubika_tls_csr_certificate" "example" {
+  metadata = {
+    namespace = "default"
+    name      = "terraform-test-tls-material"
+  }
+  spec = {
+    csr         = ubika_tls_csr.example.metadata.name
+    certificate = tls_locally_signed_cert.example.cert_pem
+    chain       = file("${path.module}/ca.crt")
+  }
+}
@@ -0,0 +1,3 @@
+# TLS materials can be imported by specifying the namespace and the name,
+# the private key is not returned by the API and must be set in the configuration
+terraform import ubika_tls_material.example default/terraform-test-tls-material
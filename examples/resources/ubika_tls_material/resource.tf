@@ -0,0 +1,11 @@
+resource "ubika_tls_material" "example" {
+  metadata = {
+    namespace = "default"
+    name      = "terraform-test-tls-material"
+  }
+  spec = {
+    certificate = file("${path.module}/example.com.crt")
+    chain       = file("${path.module}/intermediate.crt")
+    key         = file("${path.module}/example.com.key")
+  }
+}
@@ -0,0 +1,2 @@
+# Workflows can be imported by specifying the namespace and the name
+terraform import ubika_workflow.example default/terraform-test-workflow
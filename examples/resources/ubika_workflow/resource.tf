@@ -0,0 +1,25 @@
+resource "ubika_workflow" "example" {
+  metadata = {
+    namespace = "default"
+    name      = "terraform-test-workflow"
+  }
+  spec = {
+    source     = file("${path.module}/workflow.src")
+    entrypoint = "main"
+  }
+}
+
+resource "ubika_asset" "example" {
+  metadata = {
+    namespace = "default"
+    name      = "terraform-test-asset"
+  }
+  spec = {
+    hostnames       = ["terraform.example.com"]
+    backend_url     = "http://terraform.example.com/"
+    deployment_type = "SAAS"
+    custom_wkf_module = {
+      workflow = ubika_workflow.example.metadata.name
+    }
+  }
+}
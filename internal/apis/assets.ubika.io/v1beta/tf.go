@@ -1,5 +1,44 @@
 package v1beta
 
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// WorkflowParamsFromProto imports the workflow params of the custom workflow
+// module, a map field the generated FromProto does not handle.
+func (m *AssetResourceModel) WorkflowParamsFromProto(r *Asset) {
+	if m == nil || m.Spec == nil || m.Spec.CustomWkfModule == nil {
+		return
+	}
+	if params := r.GetSpec().GetCustomWkfModule().GetWorkflowParams(); len(params) > 0 {
+		m.Spec.CustomWkfModule.WorkflowParams = params
+	}
+}
+
+// WorkflowParamsToProto exports the workflow params of the custom workflow
+// module, a map field the generated ToProto does not handle.
+func (m *AssetResourceTFModel) WorkflowParamsToProto(ctx context.Context, r *Asset) diag.Diagnostics {
+	if m == nil || r.GetSpec().GetCustomWkfModule() == nil {
+		return nil
+	}
+
+	var spec *AssetSpecResourceTFModel
+	if diags := m.Spec.As(ctx, &spec, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true}); diags.HasError() || spec == nil {
+		return diags
+	}
+	var customWkfModule *CustomWkfModuleResourceTFModel
+	if diags := spec.CustomWkfModule.As(ctx, &customWkfModule, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true}); diags.HasError() || customWkfModule == nil {
+		return diags
+	}
+	if customWkfModule.WorkflowParams.IsNull() || customWkfModule.WorkflowParams.IsUnknown() {
+		return nil
+	}
+	return customWkfModule.WorkflowParams.ElementsAs(ctx, &r.Spec.CustomWkfModule.WorkflowParams, false)
+}
+
 // Status is an Unknownable
 //func (e *AssetStatusResourceModel) SetUnknown(_ context.Context, state bool) error {
 //	e.unknown = state
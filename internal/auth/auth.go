@@ -36,3 +36,24 @@ func GetToken(httpClient *http.Client, authFileBaseName string) (string, bool, e
 	}
 	return a.GetToken(), isRefresh, nil
 }
+
+// NewConfigTokenSource returns a TokenSource for a context of the auth
+// configuration, the current one if contextName is empty. Renewed tokens are
+// saved to the context, the current context of the file is left unchanged.
+func NewConfigTokenSource(httpClient *http.Client, config Config, contextName string) (TokenSource, error) {
+	if contextName == "" {
+		contextName = config.CurrentContext
+	}
+
+	a, err := config.GetContextAuthConfig(httpClient, contextName)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTokenSource(a, func(a Authentifier) error {
+		if err := config.SetContext(contextName, a); err != nil {
+			return err
+		}
+		return config.Save()
+	}), nil
+}
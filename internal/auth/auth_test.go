@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfigTokenSource(t *testing.T) {
+	c := genTestConfig(t)
+	c.UseContext("default")
+	require.NoError(t, c.SetContext("toto", NewContainerAuthConfig("key", "")))
+	require.NoError(t, c.Save())
+
+	config, err := LoadPath(c.path)
+	require.NoError(t, err)
+
+	_, err = NewConfigTokenSource(http.DefaultClient, config, "unknown")
+	require.Error(t, err)
+
+	source, err := NewConfigTokenSource(http.DefaultClient, config, "toto")
+	require.NoError(t, err)
+
+	// the container token is not issued yet, renewing it saves the context
+	_, err = source.Token()
+	require.NoError(t, err)
+
+	saved, err := LoadPath(c.path)
+	require.NoError(t, err)
+	assert.Equal(t, "default", saved.CurrentContext)
+	assert.Equal(t, containerType, saved.Contexts["toto"].AuthType)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Claims struct {
@@ -19,6 +21,7 @@ type Claims struct {
 	ResourceAccess    interface{}         `json:"resource_access,omitempty"`
 	RealmAccess       map[string][]string `json:"realm_access,omitempty"`
 	Groups            []string            `json:"groups,omitempty"`
+	AuthorizedParty   string              `json:"azp,omitempty"`
 
 	// custom properties
 	AuthType string
@@ -40,7 +43,7 @@ func NewHMACAuth(key []byte) (jwt.SigningMethod, jwt.Keyfunc) {
 // jwtCreds is JWT credentials for gRPC.
 // implements gRPC credentials.PerRPCCredentials.
 type jwtCreds struct {
-	token    string
+	source   TokenSource
 	authType string
 }
 
@@ -49,12 +52,23 @@ func NewPerRPCInternalCredentials(token string) jwtCreds {
 }
 
 func NewPerRPCCredentials(authType, token string) jwtCreds {
-	return jwtCreds{token: token, authType: authType}
+	return NewPerRPCCredentialsFromSource(authType, staticTokenSource(token))
+}
+
+// NewPerRPCCredentialsFromSource returns credentials getting a token from
+// source for each RPC.
+func NewPerRPCCredentialsFromSource(authType string, source TokenSource) jwtCreds {
+	return jwtCreds{source: source, authType: authType}
 }
 
 func (j jwtCreds) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := j.source.Token()
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
 	return map[string]string{
-		"authorization": j.authType + " " + j.token,
+		"authorization": j.authType + " " + token,
 	}, nil
 }
 
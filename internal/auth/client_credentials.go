@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// ClientCredentialsAuthConfig define OAuth2 client credentials authentication
+// configuration, used by service accounts.
+type ClientCredentialsAuthConfig struct {
+	httpClient   *http.Client
+	Type         Type   `json:"type"`
+	BaseURL      string `json:"base_url"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Scope        string `json:"scope,omitempty"`
+	AccessToken  string `json:"access_token"`
+}
+
+func NewClientCredentialsAuthConfig(client *http.Client, clientID, clientSecret, scope, url string) *ClientCredentialsAuthConfig {
+	ac := ClientCredentialsAuthConfig{
+		httpClient:   client,
+		Type:         clientCredentialsType,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scope:        scope,
+	}
+	ac.setURL(url)
+	return &ac
+}
+
+// Login authenticates the client with the authentication server.
+func (ac *ClientCredentialsAuthConfig) Login() error {
+	data := url.Values{
+		"client_id":     {ac.ClientID},
+		"client_secret": {ac.ClientSecret},
+		"grant_type":    {"client_credentials"},
+	}
+	if ac.Scope != "" {
+		data.Set("scope", ac.Scope)
+	}
+
+	resp, err := requestKCToken(ac.httpClient, ac.BaseURL+"/protocol/openid-connect/token", data)
+	if err != nil {
+		return err
+	}
+
+	ar := authResponse{}
+	if err := json.Unmarshal(resp, &ar); err != nil {
+		return err
+	}
+	ac.AccessToken = ar.AccessToken
+	return nil
+}
+
+// Renew requests a new AccessToken, the client credentials grant does not
+// issue refresh tokens.
+func (ac *ClientCredentialsAuthConfig) Renew() error {
+	return ac.Login()
+}
+
+// GetToken returns an access token for the client.
+func (ac *ClientCredentialsAuthConfig) GetToken() string {
+	return ac.AccessToken
+}
+
+// Valid returns true if current AccessToken is signed by the realm, issued
+// for the client and not expired.
+func (ac *ClientCredentialsAuthConfig) Valid() bool {
+	return verifyKCToken(ac.httpClient, ac.BaseURL, ac.ClientID, ac.AccessToken) == nil
+}
+
+// GetType returns the type of the Auth Configuration.
+func (ac *ClientCredentialsAuthConfig) GetType() Type {
+	return clientCredentialsType
+}
+
+func (ac *ClientCredentialsAuthConfig) setURL(url string) {
+	ac.BaseURL = kcBaseURL(url)
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCredentialsLogin(t *testing.T) {
+	logins := 0
+
+	// create a fake auth server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+
+		if r.URL.Path == "/auth/realms/main/protocol/openid-connect/token" &&
+			r.PostForm.Get("grant_type") == "client_credentials" &&
+			r.PostForm.Get("client_id") == "good" &&
+			r.PostForm.Get("client_secret") == "secret" {
+			logins++
+			fmt.Fprintf(w, `{"access_token": "access_token_%d", "scope": %q}`+"\n", logins, r.PostForm.Get("scope"))
+			return
+		}
+
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintln(w, `{"error": "unauthorized_client", "error_description": "Invalid client secret"}`)
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name    string
+		ac      *ClientCredentialsAuthConfig
+		wantErr bool
+	}{
+		{"good_login", NewClientCredentialsAuthConfig(http.DefaultClient, "good", "secret", "", server.URL), false},
+		{"good_login_with_scope", NewClientCredentialsAuthConfig(http.DefaultClient, "good", "secret", "openid", server.URL), false},
+		{"bad_secret", NewClientCredentialsAuthConfig(http.DefaultClient, "good", "bad", "", server.URL), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.ac.Login()
+
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Empty(t, tc.ac.GetToken())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("access_token_%d", logins), tc.ac.GetToken())
+
+			// renew logs in again
+			require.NoError(t, tc.ac.Renew())
+			assert.Equal(t, fmt.Sprintf("access_token_%d", logins), tc.ac.GetToken())
+		})
+	}
+}
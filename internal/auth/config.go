@@ -18,6 +18,11 @@ type Config struct {
 
 	// path is the path to the configuration file
 	path string `json:"-"`
+
+	// updated are the contexts changed since the configuration was loaded or
+	// saved, currentUpdated is true when the current context was changed.
+	updated        map[string]bool
+	currentUpdated bool
 }
 
 type Context struct {
@@ -27,7 +32,7 @@ type Context struct {
 
 // newConfig returns an empty Config.
 func newConfig() Config {
-	return Config{Contexts: make(map[string]Context)}
+	return Config{Contexts: make(map[string]Context), updated: make(map[string]bool)}
 }
 
 func Load(baseFileName string) (Config, error) {
@@ -47,6 +52,11 @@ func Load(baseFileName string) (Config, error) {
 		configPath = filepath.Join(cacheDir, baseFileName)
 	}
 
+	return LoadPath(configPath)
+}
+
+// LoadPath loads the auth configuration file at configPath.
+func LoadPath(configPath string) (Config, error) {
 	config := newConfig()
 	config.path = configPath
 	err := config.load()
@@ -61,6 +71,8 @@ func (c *Config) load() error {
 		}
 		return fmt.Errorf("failed to open config file: %s", err)
 	}
+	defer f.Close()
+
 	data, err := io.ReadAll(f)
 	if err != nil {
 		return fmt.Errorf("failed to read config file: %s", err)
@@ -80,51 +92,107 @@ func (c *Config) load() error {
 func (c *Config) UseContext(contextName string) {
 	if _, ok := c.Contexts[contextName]; !ok {
 		c.Contexts[contextName] = Context{}
+		c.markUpdated(contextName)
 	}
 	c.CurrentContext = contextName
+	c.currentUpdated = true
 }
 
 // Save save the auth configs to the disk.
+//
+// The file may be updated concurrently by other processes: it is locked and
+// read again, the contexts changed since the configuration was loaded are
+// merged into its latest contents, and it is replaced atomically.
 func (c *Config) Save() error {
-	data, err := json.Marshal(c)
+	unlock, err := lockFile(c.path + ".lock")
+	if err != nil {
+		return fmt.Errorf("failed to lock config file: %s", err)
+	}
+	defer unlock()
+
+	latest := newConfig()
+	latest.path = c.path
+	if err := latest.load(); err != nil {
+		return err
+	}
+
+	for contextName := range c.updated {
+		latest.Contexts[contextName] = c.Contexts[contextName]
+	}
+	if c.currentUpdated {
+		latest.CurrentContext = c.CurrentContext
+	}
+
+	data, err := json.Marshal(latest)
 	if err != nil {
 		return err
 	}
 
-	// write auth cache file
-	f, err := os.OpenFile(c.path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err := writeFileAtomic(c.path, data); err != nil {
+		return err
+	}
+
+	*c = latest
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file renamed to path, so that
+// readers never see a partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
 	if err != nil {
 		return fmt.Errorf("failed to open config file: %s", err)
 	}
+	defer os.Remove(f.Name())
 
 	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
 	if err1 := f.Close(); err1 != nil && err == nil {
 		err = err1
-		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write config file: %s", err)
 	}
 
-	return nil
+	return os.Rename(f.Name(), path)
 }
 
 // UpdateContext update the current context with a new Autentifier.
 func (c *Config) UpdateContext(newAuth Authentifier) error {
+	if c.CurrentContext == "" {
+		c.CurrentContext = "default"
+		c.currentUpdated = true
+	}
+
+	return c.SetContext(c.CurrentContext, newAuth)
+}
+
+// SetContext update the named context with a new Autentifier, without changing
+// the current context.
+func (c *Config) SetContext(contextName string, newAuth Authentifier) error {
 	b, err := json.Marshal(newAuth)
 	if err != nil {
 		return err
 	}
 
-	if c.CurrentContext == "" {
-		c.CurrentContext = "default"
-	}
-
-	c.Contexts[c.CurrentContext] = Context{
+	c.Contexts[contextName] = Context{
 		AuthType:   newAuth.GetType(),
 		AuthConfig: json.RawMessage(b),
 	}
+	c.markUpdated(contextName)
 
 	return nil
 }
 
+func (c *Config) markUpdated(contextName string) {
+	if c.updated == nil {
+		c.updated = make(map[string]bool)
+	}
+	c.updated[contextName] = true
+}
+
 func (c *Config) IsContext(contextName string) bool {
 	_, ok := c.Contexts[contextName]
 	return ok
@@ -132,12 +200,21 @@ func (c *Config) IsContext(contextName string) bool {
 
 // GetAuthConfig returns the auth configuration corresponding to the current context.
 func (c *Config) GetAuthConfig(httpClient *http.Client) (Authentifier, error) {
-	var a Authentifier
-
 	if !c.IsContext(c.CurrentContext) {
 		return nil, fmt.Errorf("no context found for the current context \"%s\"", c.CurrentContext)
 	}
-	ctx := c.Contexts[c.CurrentContext]
+
+	return c.GetContextAuthConfig(httpClient, c.CurrentContext)
+}
+
+// GetContextAuthConfig returns the auth configuration corresponding to the named context.
+func (c *Config) GetContextAuthConfig(httpClient *http.Client, contextName string) (Authentifier, error) {
+	var a Authentifier
+
+	if !c.IsContext(contextName) {
+		return nil, fmt.Errorf("no context found for the context \"%s\"", contextName)
+	}
+	ctx := c.Contexts[contextName]
 
 	switch ctx.AuthType {
 	case keycloakType:
@@ -146,6 +223,12 @@ func (c *Config) GetAuthConfig(httpClient *http.Client) (Authentifier, error) {
 		}
 	case containerType:
 		a = &ContainerAuthConfig{}
+	case tokenType:
+		a = &TokenAuthConfig{}
+	case clientCredentialsType:
+		a = &ClientCredentialsAuthConfig{
+			httpClient: httpClient,
+		}
 	default:
 		return nil, errors.New("unknown authentication configuration")
 	}
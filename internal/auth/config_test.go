@@ -1,8 +1,11 @@
 package auth
 
 import (
+	"fmt"
 	"net/http"
+	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -58,6 +61,57 @@ func TestSave(t *testing.T) {
 	assert.True(t, inMap)
 }
 
+func TestSaveMerge(t *testing.T) {
+	c := genTestConfig(t)
+
+	// two processes load the same configuration
+	first, err := LoadPath(c.path)
+	assert.NoError(t, err)
+	second, err := LoadPath(c.path)
+	assert.NoError(t, err)
+
+	assert.NoError(t, first.SetContext("default", &ContainerAuthConfig{Key: []byte("first")}))
+	assert.NoError(t, first.Save())
+
+	assert.NoError(t, second.SetContext("other", &ContainerAuthConfig{Key: []byte("second")}))
+	assert.NoError(t, second.Save())
+
+	// both updates are kept, and the current context is unchanged
+	err = c.load()
+	assert.NoError(t, err)
+	assert.Equal(t, "service_account", c.CurrentContext)
+	assert.Equal(t, first.Contexts["default"], c.Contexts["default"])
+	assert.Equal(t, second.Contexts["other"], c.Contexts["other"])
+	assert.Equal(t, c.Contexts, second.Contexts, "saved config should be up to date")
+
+	// no temporary file is left next to the config and lock files
+	entries, err := os.ReadDir(filepath.Dir(c.path))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestSaveConcurrent(t *testing.T) {
+	c := initConfig(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			config, err := LoadPath(c.path)
+			assert.NoError(t, err)
+			assert.NoError(t, config.SetContext(fmt.Sprintf("ctx_%d", i), &ContainerAuthConfig{}))
+			assert.NoError(t, config.Save())
+		}(i)
+	}
+	wg.Wait()
+
+	err := c.load()
+	assert.NoError(t, err)
+	assert.Len(t, c.Contexts, 20)
+}
+
 func TestUpdateContext(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -110,6 +164,7 @@ func TestGetAuthConfig(t *testing.T) {
 	}{
 		{"use toto context", "toto", containerType},
 		{"switch to default context", "default", keycloakType},
+		{"use service account context", "service_account", clientCredentialsType},
 	}
 
 	c := genTestConfig(t)
@@ -122,11 +177,35 @@ func TestGetAuthConfig(t *testing.T) {
 	}
 }
 
+func TestGetContextAuthConfig(t *testing.T) {
+	c := genTestConfig(t)
+	c.UseContext("default")
+
+	a, err := c.GetContextAuthConfig(http.DefaultClient, "toto")
+	assert.NoError(t, err)
+	assert.Equal(t, containerType, a.GetType())
+	assert.Equal(t, "default", c.CurrentContext)
+
+	_, err = c.GetContextAuthConfig(http.DefaultClient, "unknown")
+	assert.Error(t, err)
+}
+
+func TestSetContext(t *testing.T) {
+	c := initConfig(t)
+	c.UseContext("default")
+
+	err := c.SetContext("other", &ContainerAuthConfig{})
+	assert.NoError(t, err)
+	assert.Equal(t, "default", c.CurrentContext)
+	assert.Equal(t, containerType, c.Contexts["other"].AuthType)
+}
+
 // genTestConfig generates a fake config for testing.
 func genTestConfig(t *testing.T) Config {
 	c := initConfig(t)
 	a1 := KeycloakAuthConfig{}
 	a2 := ContainerAuthConfig{}
+	a3 := ClientCredentialsAuthConfig{}
 
 	c.UseContext("default")
 	c.UpdateContext(&a1)
@@ -134,6 +213,9 @@ func genTestConfig(t *testing.T) Config {
 	c.UseContext("toto")
 	c.UpdateContext(&a2)
 
+	c.UseContext("service_account")
+	c.UpdateContext(&a3)
+
 	_ = c.Save()
 
 	return c
@@ -16,8 +16,10 @@ type BaseAuthConfig struct {
 }
 
 const (
-	keycloakType  Type = "keycloak"
-	containerType Type = "container"
+	keycloakType          Type = "keycloak"
+	containerType         Type = "container"
+	tokenType             Type = "token"
+	clientCredentialsType Type = "client_credentials"
 )
 
 func (ac *BaseAuthConfig) Login() error     { return nil }
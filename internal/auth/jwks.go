@@ -0,0 +1,193 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// jwksCacheTTL is how long the signing keys of a realm are cached.
+	jwksCacheTTL = time.Hour
+
+	// jwksMinRefreshInterval limits how often the signing keys of a realm are
+	// fetched again when a token is signed by an unknown key.
+	jwksMinRefreshInterval = time.Minute
+)
+
+// realmKeys are the signing keys of a Keycloak realm.
+type realmKeys struct {
+	issuer  string
+	keys    map[string]*rsa.PublicKey
+	fetched time.Time
+}
+
+var (
+	realmKeysMu    sync.Mutex
+	realmKeysCache = make(map[string]*realmKeys)
+)
+
+// oidcDiscovery is the subset of the OpenID Connect discovery document used to
+// verify tokens.
+type oidcDiscovery struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// jwk is a JSON Web Key.
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// getRealmKeys returns the cached signing keys of a Keycloak realm. They are
+// fetched again when the cache is expired, or when refresh is true and they
+// were not fetched recently.
+func getRealmKeys(client *http.Client, baseURL string, refresh bool) (*realmKeys, error) {
+	realmKeysMu.Lock()
+	defer realmKeysMu.Unlock()
+
+	cached, ok := realmKeysCache[baseURL]
+	if ok && time.Since(cached.fetched) < jwksCacheTTL && (!refresh || time.Since(cached.fetched) < jwksMinRefreshInterval) {
+		return cached, nil
+	}
+
+	keys, err := fetchRealmKeys(client, baseURL)
+	if err != nil {
+		return nil, err
+	}
+	realmKeysCache[baseURL] = keys
+	return keys, nil
+}
+
+// fetchRealmKeys fetches the signing keys of a Keycloak realm from the JWKS
+// referenced by its OpenID Connect discovery document.
+func fetchRealmKeys(client *http.Client, baseURL string) (*realmKeys, error) {
+	discovery := oidcDiscovery{}
+	if err := getJSON(client, baseURL+"/.well-known/openid-configuration", &discovery); err != nil {
+		return nil, fmt.Errorf("failed to get OpenID configuration: %w", err)
+	}
+	if discovery.JWKSURI == "" {
+		return nil, errors.New("no jwks_uri in OpenID configuration")
+	}
+
+	jwks := struct {
+		Keys []jwk `json:"keys"`
+	}{}
+	if err := getJSON(client, discovery.JWKSURI, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to get JWKS: %w", err)
+	}
+
+	keys := &realmKeys{
+		issuer:  discovery.Issuer,
+		keys:    make(map[string]*rsa.PublicKey, len(jwks.Keys)),
+		fetched: time.Now(),
+	}
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		key, err := k.rsaPublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q: %w", k.Kid, err)
+		}
+		keys.keys[k.Kid] = key
+	}
+	return keys, nil
+}
+
+func (k jwk) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, err
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("exponent too large")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(exponent.Int64()),
+	}, nil
+}
+
+// verifyKCToken verifies the RS256 signature, the issuer, the audience and
+// the expiration of a Keycloak access token. The audience matches either the
+// aud or the azp claim.
+func verifyKCToken(client *http.Client, baseURL, audience, accessToken string) error {
+	keys, err := getRealmKeys(client, baseURL, false)
+	if err != nil {
+		return err
+	}
+
+	claims := Claims{}
+	_, err = jwt.ParseWithClaims(accessToken, &claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := keys.keys[kid]; ok {
+			return key, nil
+		}
+
+		// the realm keys may have been rotated
+		if keys, err = getRealmKeys(client, baseURL, true); err != nil {
+			return nil, err
+		}
+		if key, ok := keys.keys[kid]; ok {
+			return key, nil
+		}
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}))
+	if err != nil {
+		return err
+	}
+
+	if !claims.VerifyIssuer(keys.issuer, true) {
+		return fmt.Errorf("unexpected token issuer %q", claims.Issuer)
+	}
+	if !claims.VerifyAudience(audience, true) && claims.AuthorizedParty != audience {
+		return fmt.Errorf("token not issued for %q", audience)
+	}
+	return nil
+}
+
+// getJSON gets and decodes a JSON document.
+func getJSON(client *http.Client, url string, v interface{}) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRealm is a fake Keycloak realm serving its OpenID configuration and
+// JWKS.
+type fakeRealm struct {
+	*httptest.Server
+	keys        map[string]*rsa.PrivateKey
+	jwksFetches int
+}
+
+func newFakeRealm(t *testing.T) *fakeRealm {
+	realm := &fakeRealm{keys: map[string]*rsa.PrivateKey{}}
+	realm.rotate(t, "first")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(defaultAuthPath+"/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(oidcDiscovery{
+			Issuer:  realm.issuer(),
+			JWKSURI: realm.issuer() + "/protocol/openid-connect/certs",
+		})
+	})
+	mux.HandleFunc(defaultAuthPath+"/protocol/openid-connect/certs", func(w http.ResponseWriter, r *http.Request) {
+		realm.jwksFetches++
+		keys := []jwk{{Kid: "encryption", Kty: "RSA", Use: "enc", N: "AQAB", E: "AQAB"}}
+		for kid, key := range realm.keys {
+			keys = append(keys, jwk{
+				Kid: kid,
+				Kty: "RSA",
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+	})
+	realm.Server = httptest.NewServer(mux)
+	t.Cleanup(realm.Close)
+
+	return realm
+}
+
+func (r *fakeRealm) issuer() string {
+	return r.URL + defaultAuthPath
+}
+
+// rotate adds a new signing key to the realm.
+func (r *fakeRealm) rotate(t *testing.T, kid string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	r.keys[kid] = key
+}
+
+func (r *fakeRealm) sign(t *testing.T, kid string, claims Claims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	ss, err := token.SignedString(r.keys[kid])
+	require.NoError(t, err)
+	return ss
+}
+
+func TestVerifyKCToken(t *testing.T) {
+	realm := newFakeRealm(t)
+	otherRealm := newFakeRealm(t)
+
+	claims := func(issuer, audience, azp string, expiresIn time.Duration) Claims {
+		return Claims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    issuer,
+				Audience:  jwt.ClaimStrings{audience},
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIn)),
+			},
+			AuthorizedParty: azp,
+		}
+	}
+
+	hmacToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims(realm.issuer(), "account", kcClientID, time.Hour)).SignedString([]byte("key"))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"valid_azp", realm.sign(t, "first", claims(realm.issuer(), "account", kcClientID, time.Hour)), false},
+		{"valid_aud", realm.sign(t, "first", claims(realm.issuer(), kcClientID, "other", time.Hour)), false},
+		{"expired", realm.sign(t, "first", claims(realm.issuer(), "account", kcClientID, -time.Hour)), true},
+		{"wrong_issuer", realm.sign(t, "first", claims(otherRealm.issuer(), "account", kcClientID, time.Hour)), true},
+		{"wrong_audience", realm.sign(t, "first", claims(realm.issuer(), "account", "other", time.Hour)), true},
+		{"other_realm_key", otherRealm.sign(t, "first", claims(realm.issuer(), "account", kcClientID, time.Hour)), true},
+		{"hmac", hmacToken, true},
+		{"malformed", "malformed", true},
+		{"empty", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifyKCToken(http.DefaultClient, realm.issuer(), kcClientID, tc.token)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			ac := NewKeycloakAuthConfig(http.DefaultClient, "username", "pwd", realm.URL)
+			ac.AccessToken = tc.token
+			assert.Equal(t, !tc.wantErr, ac.Valid())
+		})
+	}
+}
+
+func TestVerifyKCTokenKeyRotation(t *testing.T) {
+	realm := newFakeRealm(t)
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    realm.issuer(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		AuthorizedParty: kcClientID,
+	}
+
+	require.NoError(t, verifyKCToken(http.DefaultClient, realm.issuer(), kcClientID, realm.sign(t, "first", claims)))
+	require.NoError(t, verifyKCToken(http.DefaultClient, realm.issuer(), kcClientID, realm.sign(t, "first", claims)))
+	assert.Equal(t, 1, realm.jwksFetches, "keys should be cached")
+
+	// keys were fetched recently, the new key is not known yet
+	realm.rotate(t, "second")
+	require.Error(t, verifyKCToken(http.DefaultClient, realm.issuer(), kcClientID, realm.sign(t, "second", claims)))
+	assert.Equal(t, 1, realm.jwksFetches)
+
+	// keys are fetched again for an unknown key
+	realmKeysMu.Lock()
+	realmKeysCache[realm.issuer()].fetched = time.Now().Add(-jwksMinRefreshInterval)
+	realmKeysMu.Unlock()
+	require.NoError(t, verifyKCToken(http.DefaultClient, realm.issuer(), kcClientID, realm.sign(t, "second", claims)))
+	assert.Equal(t, 2, realm.jwksFetches)
+}
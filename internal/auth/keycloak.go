@@ -10,8 +10,6 @@ import (
 	"net/url"
 	"strings"
 	"time"
-
-	"github.com/golang-jwt/jwt/v4"
 )
 
 // authResponse is a Keycloak authentication response.
@@ -55,6 +53,9 @@ var (
 	defaultAuthScheme string = "https://"
 )
 
+// kcClientID is the Keycloak client used by appsecctl.
+const kcClientID = "appsecctl"
+
 func NewKeycloakAuthConfig(client *http.Client, username, pwd, url string) *KeycloakAuthConfig {
 	ac := KeycloakAuthConfig{
 		httpClient: client,
@@ -89,7 +90,7 @@ func (ac *KeycloakAuthConfig) loginDeviceAuthzGrant() error {
 		ac.httpClient,
 		ac.BaseURL+"/protocol/openid-connect/auth/device",
 		url.Values{
-			"client_id": {"appsecctl"},
+			"client_id": {kcClientID},
 			"scope":     {"offline_access"},
 		},
 	)
@@ -125,7 +126,7 @@ LOOP:
 				ac.BaseURL+"/protocol/openid-connect/token",
 				url.Values{
 					"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
-					"client_id":   {"appsecctl"},
+					"client_id":   {kcClientID},
 					"device_code": {da.DeviceCode},
 				},
 			)
@@ -150,7 +151,7 @@ func (ac *KeycloakAuthConfig) loginWithPassword() error {
 		url.Values{
 			"username":   {ac.Username},
 			"password":   {ac.password},
-			"client_id":  {"appsecctl"},
+			"client_id":  {kcClientID},
 			"grant_type": {"password"},
 			"scope":      {"offline_access"},
 		},
@@ -168,7 +169,7 @@ func (ac *KeycloakAuthConfig) Renew() error {
 		ac.httpClient,
 		ac.BaseURL+"/protocol/openid-connect/token",
 		url.Values{
-			"client_id":     {"appsecctl"},
+			"client_id":     {kcClientID},
 			"grant_type":    {"refresh_token"},
 			"refresh_token": {ac.RefreshToken},
 		},
@@ -185,20 +186,10 @@ func (ac *KeycloakAuthConfig) GetToken() string {
 	return ac.AccessToken
 }
 
-// Valid returns true if current AccessToken is valid or not expired.
+// Valid returns true if current AccessToken is signed by the realm, issued
+// for appsecctl and not expired.
 func (ac *KeycloakAuthConfig) Valid() bool {
-	_, keyFunc := NewRSAAuth()
-	token, err := jwt.Parse(ac.AccessToken, keyFunc)
-
-	// let the backend validate the token
-	// force renew if expired
-	if token.Valid {
-		return true
-	} else if ve, ok := err.(*jwt.ValidationError); ok {
-		return ve.Errors&(jwt.ValidationErrorExpired|jwt.ValidationErrorNotValidYet) == 0
-	} else {
-		return false
-	}
+	return verifyKCToken(ac.httpClient, ac.BaseURL, kcClientID, ac.AccessToken) == nil
 }
 
 // GetType returns the type of the Auth Configuration.
@@ -207,6 +198,11 @@ func (ac *KeycloakAuthConfig) GetType() Type {
 }
 
 func (ac *KeycloakAuthConfig) setURL(url string) {
+	ac.BaseURL = kcBaseURL(url)
+}
+
+// kcBaseURL returns the realm URL of a Keycloak authentication server.
+func kcBaseURL(url string) string {
 	// add scheme if missing in the URL
 	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
 		url = defaultAuthScheme + url
@@ -217,7 +213,7 @@ func (ac *KeycloakAuthConfig) setURL(url string) {
 		url += defaultAuthPath
 	}
 
-	ac.BaseURL = strings.TrimSuffix(url, "/")
+	return strings.TrimSuffix(url, "/")
 }
 
 // requestKCToken requests new token from Keycloak authentication server.
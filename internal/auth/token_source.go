@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// renewBefore is how long before its expiration an access token is renewed.
+const renewBefore = time.Minute
+
+// TokenSource returns access tokens.
+type TokenSource interface {
+	Token() (string, error)
+}
+
+// staticTokenSource is a TokenSource always returning the same access token.
+type staticTokenSource string
+
+func (s staticTokenSource) Token() (string, error) {
+	return string(s), nil
+}
+
+// authTokenSource is a TokenSource renewing the access token of an
+// Authentifier shortly before it expires. It is safe for concurrent use.
+type authTokenSource struct {
+	mu      sync.Mutex
+	a       Authentifier
+	onRenew func(Authentifier) error
+	now     func() time.Time
+}
+
+// NewTokenSource returns a TokenSource for a logged in Authentifier. onRenew,
+// if not nil, is called after each renewal, e.g. to save the new tokens. Its
+// errors are logged, the renewed token being returned anyway.
+func NewTokenSource(a Authentifier, onRenew func(Authentifier) error) TokenSource {
+	return &authTokenSource{
+		a:       a,
+		onRenew: onRenew,
+		now:     time.Now,
+	}
+}
+
+// Token returns the current access token, renewed if it is expired or about
+// to expire.
+func (s *authTokenSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	valid := s.a.Valid()
+	if valid && !s.expiresSoon() {
+		return s.a.GetToken(), nil
+	}
+
+	if err := s.a.Renew(); err != nil {
+		if valid {
+			// keep using the current token until it expires
+			return s.a.GetToken(), nil
+		}
+		return "", fmt.Errorf("failed to renew access token: %w", err)
+	}
+
+	// the renewed token is valid even if it cannot be saved, e.g. in a read
+	// only cache directory, it is then only kept in memory
+	if s.onRenew != nil {
+		if err := s.onRenew(s.a); err != nil {
+			log.Printf("[WARN] Unable to save the renewed access token: %s", err)
+		}
+	}
+
+	return s.a.GetToken(), nil
+}
+
+// expiresSoon returns true if the access token is a JWT expiring in less than
+// renewBefore.
+func (s *authTokenSource) expiresSoon() bool {
+	claims := jwt.RegisteredClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(s.a.GetToken(), &claims); err != nil || claims.ExpiresAt == nil {
+		return false
+	}
+	return s.now().Add(renewBefore).After(claims.ExpiresAt.Time)
+}
@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAuthentifier issues HMAC signed tokens, renewed tokens expire in an hour.
+type fakeAuthentifier struct {
+	BaseAuthConfig
+	token    string
+	renewals int
+	renewErr error
+}
+
+func (f *fakeAuthentifier) issue(ttl time.Duration) {
+	method, _ := NewHMACAuth([]byte("key"))
+	f.token, _ = jwt.NewWithClaims(method, jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+	}).SignedString([]byte("key"))
+}
+
+func (f *fakeAuthentifier) Renew() error {
+	if f.renewErr != nil {
+		return f.renewErr
+	}
+	f.renewals++
+	f.issue(time.Hour)
+	return nil
+}
+
+func (f *fakeAuthentifier) Valid() bool {
+	_, keyFunc := NewHMACAuth([]byte("key"))
+	_, err := jwt.Parse(f.token, keyFunc)
+	return err == nil
+}
+
+func (f *fakeAuthentifier) GetToken() string {
+	return f.token
+}
+
+func TestTokenSource(t *testing.T) {
+	testCases := []struct {
+		name         string
+		ttl          time.Duration
+		renewErr     error
+		saveErr      error
+		wantRenewals int
+		wantErr      bool
+	}{
+		{"valid", time.Hour, nil, nil, 0, false},
+		{"expiring_soon", 30 * time.Second, nil, nil, 1, false},
+		{"expired", -time.Hour, nil, nil, 1, false},
+		{"expiring_soon_renew_failure", 30 * time.Second, errors.New("renew"), nil, 0, false},
+		{"expired_renew_failure", -time.Hour, errors.New("renew"), nil, 0, true},
+		{"expired_save_failure", -time.Hour, nil, errors.New("read-only file system"), 1, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := &fakeAuthentifier{renewErr: tc.renewErr}
+			a.issue(tc.ttl)
+			issued := a.token
+
+			saved := 0
+			source := NewTokenSource(a, func(Authentifier) error {
+				saved++
+				return tc.saveErr
+			})
+
+			token, err := source.Token()
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, a.token, token)
+			assert.Equal(t, tc.wantRenewals, a.renewals)
+			assert.Equal(t, tc.wantRenewals, saved)
+			if tc.wantRenewals == 0 {
+				assert.Equal(t, issued, token)
+			}
+		})
+	}
+}
+
+func TestTokenSourceConcurrent(t *testing.T) {
+	a := &fakeAuthentifier{}
+	a.issue(30 * time.Second)
+	source := NewTokenSource(a, nil).(*authTokenSource)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := source.Token()
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, a.renewals)
+}
+
+func TestPerRPCCredentialsFromSource(t *testing.T) {
+	a := &fakeAuthentifier{}
+	a.issue(time.Hour)
+
+	md, err := NewPerRPCCredentialsFromSource("bearer", NewTokenSource(a, nil)).GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "bearer " + a.token}, md)
+
+	a = &fakeAuthentifier{renewErr: errors.New("renew")}
+	a.issue(-time.Hour)
+
+	_, err = NewPerRPCCredentialsFromSource("bearer", NewTokenSource(a, nil)).GetRequestMetadata(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
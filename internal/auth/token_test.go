@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenAuthConfig(t *testing.T) {
+	sign := func(expiresAt time.Time) string {
+		method, _ := NewHMACAuth([]byte("key"))
+		token, err := jwt.NewWithClaims(method, jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(expiresAt)}).SignedString([]byte("key"))
+		require.NoError(t, err)
+		return token
+	}
+
+	testCases := []struct {
+		name      string
+		token     string
+		wantValid bool
+	}{
+		{"empty", "", false},
+		{"opaque", "opaque_token", true},
+		{"jwt", sign(time.Now().Add(time.Hour)), true},
+		{"expired_jwt", sign(time.Now().Add(-time.Hour)), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ac := NewTokenAuthConfig(tc.token)
+
+			require.NoError(t, ac.Login())
+			assert.Equal(t, tc.token, ac.GetToken())
+			assert.Equal(t, tc.wantValid, ac.Valid())
+			assert.Error(t, ac.Renew())
+		})
+	}
+}
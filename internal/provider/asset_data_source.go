@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AssetDataSource{}
+
+func NewAssetDataSource() datasource.DataSource {
+	return &AssetDataSource{}
+}
+
+// AssetDataSource defines the data source implementation.
+type AssetDataSource struct {
+	client assetsv1.Client
+}
+
+func (d *AssetDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asset"
+}
+
+func (d *AssetDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Asset data source",
+
+		Attributes: lookupDataSourceAttributes(ctx, NewAssetResource()),
+	}
+}
+
+func (d *AssetDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(assetsv1.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *assetsv1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AssetDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "Reading Asset")
+
+	// Read Terraform configuration data into the model
+	var config *assetsv1.AssetResourceTFModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var meta metav1.ObjectMetaResourceTFModel
+	resp.Diagnostics.Append(config.Metadata.As(ctx, &meta, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	asset, err := d.client.Asset().Get(ctx, &metav1.GetOptions{
+		Name:      meta.Name.ValueString(),
+		Namespace: meta.Namespace.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read asset %s/%s, got error: %s", meta.Namespace.ValueString(), meta.Name.ValueString(), err))
+		return
+	}
+
+	// generate state from protobuf resource
+	var state assetsv1.AssetResourceModel
+	_, err = state.FromProto(asset)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get state from asset %s/%s, got error: %s", meta.Namespace.ValueString(), meta.Name.ValueString(), err))
+		return
+	}
+	state.WorkflowParamsFromProto(asset)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
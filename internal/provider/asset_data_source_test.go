@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAssetDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAssetDataSourceConfig("tf-acc-test", "tf-acc-tests"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ubika_asset.test", "id", "tf-acc-tests/tf-acc-test"),
+					resource.TestCheckResourceAttrPair("data.ubika_asset.test", "spec.backend_url", "ubika_asset.test", "spec.backend_url"),
+					resource.TestCheckResourceAttrSet("data.ubika_asset.test", "status.service_address"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAssetDataSourceConfig(name string, namespace string) string {
+	return testAccAssetResourceConfig(name, namespace) + fmt.Sprintf(`
+data "ubika_asset" "test" {
+  metadata = {
+    name = ubika_asset.test.metadata.name
+    namespace = %[1]q
+  }
+}
+`, namespace)
+}
@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -31,6 +35,82 @@ type AssetResource struct {
 	client assetsv1.Client
 }
 
+// assetResourceModel is the state model of the resource. It differs from the
+// generated assetsv1.AssetResourceModel by the wait_for and timeouts blocks
+// which only exist on the Terraform side.
+type assetResourceModel struct {
+	Id       string                             `tfsdk:"id"`
+	Metadata *metav1.ObjectMetaResourceModel    `tfsdk:"metadata"`
+	Spec     *assetsv1.AssetSpecResourceModel   `tfsdk:"spec"`
+	Status   *assetsv1.AssetStatusResourceModel `tfsdk:"status"`
+	WaitFor  *assetWaitForModel                 `tfsdk:"wait_for"`
+	Timeouts timeouts.Value                     `tfsdk:"timeouts"`
+}
+
+// assetResourceTFModel is the plan model of the resource.
+type assetResourceTFModel struct {
+	Id       types.String       `tfsdk:"id"`
+	Metadata types.Object       `tfsdk:"metadata"`
+	Spec     types.Object       `tfsdk:"spec"`
+	Status   types.Object       `tfsdk:"status"`
+	WaitFor  *assetWaitForModel `tfsdk:"wait_for"`
+	Timeouts timeouts.Value     `tfsdk:"timeouts"`
+}
+
+// ToProto converts the model to the corresponding protobuf struct
+func (m *assetResourceTFModel) ToProto(ctx context.Context) (*assetsv1.Asset, diag.Diagnostics) {
+	generated := assetsv1.AssetResourceTFModel{
+		Id:       m.Id,
+		Metadata: m.Metadata,
+		Spec:     m.Spec,
+		Status:   m.Status,
+	}
+	asset, diags := generated.ToProto(ctx)
+	if diags.HasError() {
+		return asset, diags
+	}
+	diags.Append(generated.WorkflowParamsToProto(ctx, asset)...)
+	return asset, diags
+}
+
+// newAssetState generates the state from the protobuf resource, the wait_for
+// and timeouts blocks are those of the plan or prior state. The API returning
+// no workflow params when they are empty, they are kept empty rather than null
+// when so in the prior spec.
+func newAssetState(ctx context.Context, asset *assetsv1.Asset, prior basetypes.ObjectValue, waitFor *assetWaitForModel, timeoutsValue timeouts.Value) (assetResourceModel, diag.Diagnostics) {
+	var generated assetsv1.AssetResourceModel
+	if _, err := generated.FromProto(asset); err != nil {
+		return assetResourceModel{}, []diag.Diagnostic{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to get state from asset %s/%s, got error: %s", asset.GetMetadata().GetNamespace(), asset.GetMetadata().GetName(), err))}
+	}
+	generated.WorkflowParamsFromProto(asset)
+
+	state := assetResourceModel{
+		Id:       generated.Id,
+		Metadata: generated.Metadata,
+		Spec:     generated.Spec,
+		Status:   generated.Status,
+		WaitFor:  waitFor,
+		Timeouts: timeoutsValue,
+	}
+
+	if state.Spec == nil || state.Spec.CustomWkfModule == nil || state.Spec.CustomWkfModule.WorkflowParams != nil || prior.IsNull() || prior.IsUnknown() {
+		return state, nil
+	}
+
+	var priorSpec assetsv1.AssetSpecResourceTFModel
+	if diags := prior.As(ctx, &priorSpec, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true}); diags.HasError() {
+		return assetResourceModel{}, diags
+	}
+	var priorModule *assetsv1.CustomWkfModuleResourceTFModel
+	if diags := priorSpec.CustomWkfModule.As(ctx, &priorModule, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true}); diags.HasError() {
+		return assetResourceModel{}, diags
+	}
+	if priorModule != nil && !priorModule.WorkflowParams.IsNull() && !priorModule.WorkflowParams.IsUnknown() && len(priorModule.WorkflowParams.Elements()) == 0 {
+		state.Spec.CustomWkfModule.WorkflowParams = map[string]string{}
+	}
+	return state, nil
+}
+
 func (r *AssetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_asset"
 }
@@ -116,6 +196,10 @@ func (r *AssetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 							"security_mode": schema.StringAttribute{
 								Optional: true,
 							},
+							"ip_blacklist": schema.StringAttribute{
+								MarkdownDescription: "IP blacklist resource name",
+								Required:            true,
+							},
 						},
 					},
 					"custom_wkf_module": schema.SingleNestedAttribute{
@@ -124,9 +208,10 @@ func (r *AssetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 							"workflow": schema.StringAttribute{
 								Optional: true,
 							},
-							"workflow_params": schema.SetAttribute{
-								Optional:    true,
-								ElementType: types.StringType,
+							"workflow_params": schema.MapAttribute{
+								MarkdownDescription: "Parameters of the custom workflow",
+								Optional:            true,
+								ElementType:         types.StringType,
 							},
 						},
 					},
@@ -243,6 +328,39 @@ func (r *AssetResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"wait_for": schema.SingleNestedBlock{
+				MarkdownDescription: "States the asset must reach before its creation or update completes, within the `create` and `update` timeouts.",
+				Attributes: map[string]schema.Attribute{
+					"running_state": schema.StringAttribute{
+						MarkdownDescription: "Running state, e.g. `ALIVE`",
+						Optional:            true,
+						Validators: []validator.String{
+							newEnumValidator(assetsv1.RunningState_Enum_value),
+						},
+					},
+					"backend": schema.StringAttribute{
+						MarkdownDescription: "Backend state, e.g. `OK`",
+						Optional:            true,
+						Validators: []validator.String{
+							newEnumValidator(assetsv1.BackendState_Enum_value),
+						},
+					},
+					"dns": schema.StringAttribute{
+						MarkdownDescription: "DNS state, e.g. `REDIRECTED`",
+						Optional:            true,
+						Validators: []validator.String{
+							newEnumValidator(assetsv1.DnsState_Enum_value),
+						},
+					},
+				},
+			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -270,7 +388,7 @@ func (r *AssetResource) Create(ctx context.Context, req resource.CreateRequest,
 	tflog.Info(ctx, "Creating Asset")
 
 	// Read Terraform plan data into the model
-	var plan *assetsv1.AssetResourceTFModel
+	var plan *assetResourceTFModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -290,33 +408,40 @@ func (r *AssetResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultAssetTimeout)
+	resp.Diagnostics.Append(diags...)
+	asset, waitDiags := r.wait(ctx, asset, plan.WaitFor, createTimeout)
+
 	// generate state from protobuf resource
-	var state assetsv1.AssetResourceModel
-	_, err = state.FromProto(asset)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get state from asset, got error: %s", err))
+	state, diags := newAssetState(ctx, asset, plan.Spec, plan.WaitFor, plan.Timeouts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	tflog.Trace(ctx, "created an asset")
 
-	// Save state data into Terraform state
+	// Save state data into Terraform state, even if the asset is not ready
+	// so that it is not orphaned
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	resp.Diagnostics.Append(waitDiags...)
 }
 
 func (r *AssetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	tflog.Info(ctx, "Reading Asset")
 
 	// Read Terraform prior state data into the model
-	var state *assetsv1.AssetResourceTFModel
+	var state *assetResourceTFModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	newState, diags := r.read(ctx, state.Metadata, nil)
+	newState, diags := r.read(ctx, state.Metadata, nil, state.Spec, state.WaitFor, state.Timeouts)
+	if isNotFoundDiagnostics(diags) {
+		tflog.Warn(ctx, "Asset not found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -326,11 +451,11 @@ func (r *AssetResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
 }
 
-func (r *AssetResource) read(ctx context.Context, metaObjValue basetypes.ObjectValue, meta *metav1.ObjectMetaResourceTFModel) (assetsv1.AssetResourceModel, diag.Diagnostics) {
+func (r *AssetResource) read(ctx context.Context, metaObjValue basetypes.ObjectValue, meta *metav1.ObjectMetaResourceTFModel, prior basetypes.ObjectValue, waitFor *assetWaitForModel, timeoutsValue timeouts.Value) (assetResourceModel, diag.Diagnostics) {
 	// get metadata from state
 	if meta == nil {
 		if diags := metaObjValue.As(ctx, &meta, basetypes.ObjectAsOptions{}); diags.HasError() {
-			return assetsv1.AssetResourceModel{}, diags
+			return assetResourceModel{}, diags
 		}
 	}
 
@@ -339,22 +464,19 @@ func (r *AssetResource) read(ctx context.Context, metaObjValue basetypes.ObjectV
 		Namespace: meta.Namespace.ValueString(),
 	})
 	if err != nil {
-		return assetsv1.AssetResourceModel{}, []diag.Diagnostic{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to read asset %s/%s, got error: %s", meta.Name.ValueString(), meta.Namespace.ValueString(), err))}
+		if isNotFound(err) {
+			return assetResourceModel{}, []diag.Diagnostic{notFoundDiagnostic("asset", meta)}
+		}
+		return assetResourceModel{}, []diag.Diagnostic{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to read asset %s/%s, got error: %s", meta.Name.ValueString(), meta.Namespace.ValueString(), err))}
 	}
 
 	// update state from protobuf resource
-	var state assetsv1.AssetResourceModel
-	_, err = state.FromProto(asset)
-	if err != nil {
-		return assetsv1.AssetResourceModel{}, []diag.Diagnostic{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to get state from asset %s/%s, got error: %s", meta.Name.ValueString(), meta.Namespace.ValueString(), err))}
-	}
-
-	return state, nil
+	return newAssetState(ctx, asset, prior, waitFor, timeoutsValue)
 }
 
 func (r *AssetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	// Read Terraform plan data into the model
-	var plan *assetsv1.AssetResourceTFModel
+	var plan *assetResourceTFModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -367,27 +489,65 @@ func (r *AssetResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	asset, err := r.client.Asset().Update(ctx, asset)
+	// send the prior version so that concurrent changes are detected
+	priorMeta := asset.GetMetadata()
+	resp.Diagnostics.Append(setPriorVersion(ctx, r.client, req.State, priorMeta)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only the changed fields are sent when partial updates are supported
+	svc := r.client.Asset()
+	asset, err := updateObject(ctx, r.client, svc, req, asset, svc.Update)
 	if err != nil {
+		if isConflict(err, priorMeta.GetVersion() != 0) {
+			resp.Diagnostics.Append(conflictDiagnostic("asset", priorMeta))
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update asset, got error: %s", err))
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultAssetTimeout)
+	resp.Diagnostics.Append(diags...)
+	asset, waitDiags := r.wait(ctx, asset, plan.WaitFor, updateTimeout)
+
 	// generate state from protobuf resource
-	var state assetsv1.AssetResourceModel
-	_, err = state.FromProto(asset)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get state from asset, got error: %s", err))
+	state, diags := newAssetState(ctx, asset, plan.Spec, plan.WaitFor, plan.Timeouts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(waitDiags...)
+}
+
+// wait waits for the asset to reach the states of waitFor, within timeout. It
+// returns the last read asset.
+func (r *AssetResource) wait(ctx context.Context, asset *assetsv1.Asset, waitFor *assetWaitForModel, timeout time.Duration) (*assetsv1.Asset, diag.Diagnostics) {
+	if waitFor == nil {
+		return asset, nil
+	}
+
+	tflog.Info(ctx, "Waiting for Asset")
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ready, err := waitForAsset(ctx, r.client.Asset(), asset.GetMetadata(), waitFor)
+	if ready != nil {
+		asset = ready
+	}
+	if err != nil {
+		return asset, []diag.Diagnostic{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to wait for asset, got error: %s", err))}
+	}
+	return asset, nil
 }
 
 func (r *AssetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	tflog.Info(ctx, "Deleting Asset")
-	var plan *assetsv1.AssetResourceTFModel
+	var plan *assetResourceTFModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &plan)...)
@@ -405,10 +565,34 @@ func (r *AssetResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		Name:      meta.Name.ValueString(),
 		Namespace: meta.Namespace.ValueString(),
 	})
+	if isNotFound(err) {
+		// already deleted
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete asset, got error: %s", err))
 		return
 	}
+
+	// wait for the asset to be torn down, so that its hostnames can be reused
+	deleteTimeout, diags := plan.Timeouts.Delete(ctx, defaultAssetTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Waiting for Asset deletion")
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err = waitForAssetDeletion(ctx, r.client.Asset(), &metav1.ObjectMeta{
+		Name:      meta.Name.ValueString(),
+		Namespace: meta.Namespace.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to wait for asset deletion, got error: %s", err))
+		return
+	}
 }
 
 func (r *AssetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -425,7 +609,14 @@ func (r *AssetResource) ImportState(ctx context.Context, req resource.ImportStat
 		Name:      types.StringValue(name),
 		Namespace: types.StringValue(namespace),
 	}
-	state, diags := r.read(ctx, basetypes.ObjectValue{}, &meta)
+	// the imported asset is not waited for
+	var timeoutsValue timeouts.Value
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("timeouts"), &timeoutsValue)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, diags := r.read(ctx, basetypes.ObjectValue{}, &meta, types.ObjectNull(nil), nil, timeoutsValue)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
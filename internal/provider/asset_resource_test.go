@@ -1,10 +1,16 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
 )
 
 func TestAccAssetResource(t *testing.T) {
@@ -53,3 +59,57 @@ resource "ubika_asset" "test" {
 }
 `, name, namespace)
 }
+
+func TestAssetWorkflowParams(t *testing.T) {
+	ctx := context.Background()
+
+	asset := assetsv1.NewAsset("test")
+	asset.Spec.Hostnames = []string{"tf-acc-test.example.com"}
+	asset.Spec.CustomWkfModule = &assetsv1.CustomWkfModule{
+		Workflow:       "test",
+		WorkflowParams: map[string]string{"threshold": "10", "mode": "strict"},
+	}
+	state := testAssetState(t, asset)
+
+	var tfModel *assetResourceTFModel
+	diags := state.Get(ctx, &tfModel)
+	assert.False(t, diags.HasError(), diags)
+
+	got, diags := tfModel.ToProto(ctx)
+	assert.False(t, diags.HasError(), diags)
+	assert.Equal(t, asset.Spec.CustomWkfModule.WorkflowParams, got.GetSpec().GetCustomWkfModule().GetWorkflowParams())
+}
+
+func TestAssetEmptyWorkflowParams(t *testing.T) {
+	ctx := context.Background()
+
+	// the API returns no params when they are empty
+	asset := assetsv1.NewAsset("test")
+	asset.Spec.Hostnames = []string{"tf-acc-test.example.com"}
+	asset.Spec.CustomWkfModule = &assetsv1.CustomWkfModule{Workflow: "test"}
+	state := testAssetState(t, asset)
+	paramsPath := path.Root("spec").AtName("custom_wkf_module").AtName("workflow_params")
+
+	var params types.Map
+	diags := state.GetAttribute(ctx, paramsPath, &params)
+	assert.False(t, diags.HasError(), diags)
+	assert.True(t, params.IsNull())
+
+	// empty params of the prior spec are kept empty
+	diags = state.SetAttribute(ctx, paramsPath, map[string]string{})
+	assert.False(t, diags.HasError(), diags)
+	var prior types.Object
+	var timeoutsValue timeouts.Value
+	diags = state.GetAttribute(ctx, path.Root("spec"), &prior)
+	diags.Append(state.GetAttribute(ctx, path.Root("timeouts"), &timeoutsValue)...)
+	assert.False(t, diags.HasError(), diags)
+
+	model, diags := newAssetState(ctx, asset, prior, nil, timeoutsValue)
+	assert.False(t, diags.HasError(), diags)
+	diags = state.Set(ctx, &model)
+	assert.False(t, diags.HasError(), diags)
+	diags = state.GetAttribute(ctx, paramsPath, &params)
+	assert.False(t, diags.HasError(), diags)
+	assert.False(t, params.IsNull())
+	assert.Empty(t, params.Elements())
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AssetsDataSource{}
+
+func NewAssetsDataSource() datasource.DataSource {
+	return &AssetsDataSource{}
+}
+
+// AssetsDataSource defines the data source implementation.
+type AssetsDataSource struct {
+	client assetsv1.Client
+}
+
+// AssetsDataSourceModel describes the data source data model.
+type AssetsDataSourceModel struct {
+	Namespace types.String                  `tfsdk:"namespace"`
+	Items     []assetsv1.AssetResourceModel `tfsdk:"items"`
+}
+
+func (d *AssetsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_assets"
+}
+
+func (d *AssetsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Assets data source, lists the assets of a namespace",
+
+		Attributes: listDataSourceAttributes(ctx, NewAssetResource()),
+	}
+}
+
+func (d *AssetsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(assetsv1.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *assetsv1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AssetsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "Reading Assets")
+
+	// Read Terraform configuration data into the model
+	var state AssetsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assets, err := d.client.Asset().List(ctx, &metav1.ListOptions{
+		Namespace: state.Namespace.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list assets, got error: %s", err))
+		return
+	}
+
+	// generate state from protobuf resources
+	state.Items = make([]assetsv1.AssetResourceModel, 0, len(assets.GetItems()))
+	for _, asset := range assets.GetItems() {
+		var item assetsv1.AssetResourceModel
+		if _, err := item.FromProto(asset); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get state from asset %s/%s, got error: %s", asset.GetMetadata().GetNamespace(), asset.GetMetadata().GetName(), err))
+			return
+		}
+		item.WorkflowParamsFromProto(asset)
+		state.Items = append(state.Items, item)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
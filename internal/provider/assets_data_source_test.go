@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAssetsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAssetsDataSourceConfig("tf-acc-test", "tf-acc-tests"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ubika_assets.test", "namespace", "tf-acc-tests"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.ubika_assets.test", "items.*", map[string]string{
+						"metadata.name": "tf-acc-test",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccAssetsDataSourceConfig(name string, namespace string) string {
+	return testAccAssetResourceConfig(name, namespace) + fmt.Sprintf(`
+data "ubika_assets" "test" {
+  namespace = %[1]q
+
+  depends_on = [ubika_asset.test]
+}
+`, namespace)
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// lookupDataSourceAttributes returns the attributes of a data source looking
+// up a single resource by name and namespace: the attributes of the resource,
+// all computed but the name and namespace.
+func lookupDataSourceAttributes(ctx context.Context, r resource.Resource) map[string]dsschema.Attribute {
+	var resp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resp)
+
+	attributes := computedDataSourceAttributes(resp.Schema.Attributes)
+	attributes["metadata"] = GetObjectMetaDataSource()
+	return attributes
+}
+
+// listDataSourceAttributes returns the attributes of a data source listing the
+// resources of a namespace: the namespace and the list of items with the
+// attributes of the resource, all computed.
+func listDataSourceAttributes(ctx context.Context, r resource.Resource) map[string]dsschema.Attribute {
+	var resp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resp)
+
+	return map[string]dsschema.Attribute{
+		"namespace": dsschema.StringAttribute{
+			MarkdownDescription: "Namespace of the resources to list",
+			Optional:            true,
+		},
+		"items": dsschema.ListNestedAttribute{
+			MarkdownDescription: "Resources of the namespace",
+			Computed:            true,
+			NestedObject: dsschema.NestedAttributeObject{
+				Attributes: computedDataSourceAttributes(resp.Schema.Attributes),
+			},
+		},
+	}
+}
+
+// computedDataSourceAttributes converts resource schema attributes to data
+// source attributes which are all computed, so data sources expose the same
+// attributes as the corresponding resources.
+func computedDataSourceAttributes(attributes map[string]schema.Attribute) map[string]dsschema.Attribute {
+	computed := make(map[string]dsschema.Attribute, len(attributes))
+	for name, attribute := range attributes {
+		computed[name] = computedDataSourceAttribute(attribute)
+	}
+	return computed
+}
+
+func computedDataSourceAttribute(attribute schema.Attribute) dsschema.Attribute {
+	switch a := attribute.(type) {
+	case schema.StringAttribute:
+		return dsschema.StringAttribute{Computed: true, Sensitive: a.Sensitive, MarkdownDescription: a.MarkdownDescription}
+	case schema.BoolAttribute:
+		return dsschema.BoolAttribute{Computed: true, Sensitive: a.Sensitive, MarkdownDescription: a.MarkdownDescription}
+	case schema.Int64Attribute:
+		return dsschema.Int64Attribute{Computed: true, Sensitive: a.Sensitive, MarkdownDescription: a.MarkdownDescription}
+	case schema.Float64Attribute:
+		return dsschema.Float64Attribute{Computed: true, Sensitive: a.Sensitive, MarkdownDescription: a.MarkdownDescription}
+	case schema.NumberAttribute:
+		return dsschema.NumberAttribute{Computed: true, Sensitive: a.Sensitive, MarkdownDescription: a.MarkdownDescription}
+	case schema.ListAttribute:
+		return dsschema.ListAttribute{Computed: true, Sensitive: a.Sensitive, MarkdownDescription: a.MarkdownDescription, ElementType: a.ElementType}
+	case schema.SetAttribute:
+		return dsschema.SetAttribute{Computed: true, Sensitive: a.Sensitive, MarkdownDescription: a.MarkdownDescription, ElementType: a.ElementType}
+	case schema.MapAttribute:
+		return dsschema.MapAttribute{Computed: true, Sensitive: a.Sensitive, MarkdownDescription: a.MarkdownDescription, ElementType: a.ElementType}
+	case schema.ObjectAttribute:
+		return dsschema.ObjectAttribute{Computed: true, Sensitive: a.Sensitive, MarkdownDescription: a.MarkdownDescription, AttributeTypes: a.AttributeTypes}
+	case schema.SingleNestedAttribute:
+		return dsschema.SingleNestedAttribute{
+			Computed:            true,
+			Sensitive:           a.Sensitive,
+			MarkdownDescription: a.MarkdownDescription,
+			Attributes:          computedDataSourceAttributes(a.Attributes),
+		}
+	case schema.ListNestedAttribute:
+		return dsschema.ListNestedAttribute{
+			Computed:            true,
+			Sensitive:           a.Sensitive,
+			MarkdownDescription: a.MarkdownDescription,
+			NestedObject:        dsschema.NestedAttributeObject{Attributes: computedDataSourceAttributes(a.NestedObject.Attributes)},
+		}
+	case schema.SetNestedAttribute:
+		return dsschema.SetNestedAttribute{
+			Computed:            true,
+			Sensitive:           a.Sensitive,
+			MarkdownDescription: a.MarkdownDescription,
+			NestedObject:        dsschema.NestedAttributeObject{Attributes: computedDataSourceAttributes(a.NestedObject.Attributes)},
+		}
+	case schema.MapNestedAttribute:
+		return dsschema.MapNestedAttribute{
+			Computed:            true,
+			Sensitive:           a.Sensitive,
+			MarkdownDescription: a.MarkdownDescription,
+			NestedObject:        dsschema.NestedAttributeObject{Attributes: computedDataSourceAttributes(a.NestedObject.Attributes)},
+		}
+	default:
+		panic(fmt.Sprintf("unsupported resource attribute type %T", attribute))
+	}
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputedDataSourceAttributes(t *testing.T) {
+	ctx := context.Background()
+
+	p := &UbikaProvider{}
+	for _, newResource := range p.Resources(ctx) {
+		r := newResource()
+
+		var metadataResp resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "ubika"}, &metadataResp)
+
+		t.Run(metadataResp.TypeName, func(t *testing.T) {
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+			attributes := computedDataSourceAttributes(schemaResp.Schema.Attributes)
+			assert.Len(t, attributes, len(schemaResp.Schema.Attributes))
+			assertComputedAttributes(t, attributes)
+
+			s := dsschema.Schema{Attributes: attributes}
+			assert.False(t, s.ValidateImplementation(ctx).HasError())
+		})
+	}
+}
+
+func assertComputedAttributes(t *testing.T, attributes map[string]dsschema.Attribute) {
+	t.Helper()
+
+	for name, attribute := range attributes {
+		assert.True(t, attribute.IsComputed(), name)
+		assert.False(t, attribute.IsRequired() || attribute.IsOptional(), name)
+
+		switch a := attribute.(type) {
+		case dsschema.SingleNestedAttribute:
+			assertComputedAttributes(t, a.Attributes)
+		case dsschema.ListNestedAttribute:
+			assertComputedAttributes(t, a.NestedObject.Attributes)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccErrorDocumentDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccErrorDocumentDataSourceConfig("tf-acc-test", "tf-acc-tests"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ubika_error_document.test", "id", "tf-acc-tests/tf-acc-test"),
+					resource.TestCheckResourceAttrPair("data.ubika_error_document.test", "spec.page", "ubika_error_document.test", "spec.page"),
+				),
+			},
+		},
+	})
+}
+
+func testAccErrorDocumentDataSourceConfig(name string, namespace string) string {
+	return testAccErrorDocumentResourceConfig(name, namespace) + fmt.Sprintf(`
+data "ubika_error_document" "test" {
+  metadata = {
+    name = ubika_error_document.test.metadata.name
+    namespace = %[1]q
+  }
+}
+`, namespace)
+}
@@ -130,6 +130,11 @@ func (r *ErrorDocumentResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 	newState, diags := r.read(ctx, state.Metadata, nil)
+	if isNotFoundDiagnostics(diags) {
+		tflog.Warn(ctx, "Error document not found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -152,6 +157,9 @@ func (r *ErrorDocumentResource) read(ctx context.Context, metaObjValue basetypes
 		Namespace: meta.Namespace.ValueString(),
 	})
 	if err != nil {
+		if isNotFound(err) {
+			return assetsv1.ErrorDocumentResourceModel{}, []diag.Diagnostic{notFoundDiagnostic("error document", meta)}
+		}
 		return assetsv1.ErrorDocumentResourceModel{}, []diag.Diagnostic{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to read error document %s/%s, got error: %s", meta.Name.ValueString(), meta.Namespace.ValueString(), err))}
 	}
 
@@ -179,8 +187,21 @@ func (r *ErrorDocumentResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	errorDocument, err := r.client.ErrorDocument().Update(ctx, errorDocument)
+	// send the prior version so that concurrent changes are detected
+	priorMeta := errorDocument.GetMetadata()
+	resp.Diagnostics.Append(setPriorVersion(ctx, r.client, req.State, priorMeta)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only the changed fields are sent when partial updates are supported
+	svc := r.client.ErrorDocument()
+	errorDocument, err := updateObject(ctx, r.client, svc, req, errorDocument, svc.Update)
 	if err != nil {
+		if isConflict(err, priorMeta.GetVersion() != 0) {
+			resp.Diagnostics.Append(conflictDiagnostic("error document", priorMeta))
+			return
+		}
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update error document, got error: %s", err))
 		return
 	}
@@ -217,6 +238,10 @@ func (r *ErrorDocumentResource) Delete(ctx context.Context, req resource.DeleteR
 		Name:      meta.Name.ValueString(),
 		Namespace: meta.Namespace.ValueString(),
 	})
+	if isNotFound(err) {
+		// already deleted
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete error document, got error: %s", err))
 		return
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ErrorDocumentsDataSource{}
+
+func NewErrorDocumentsDataSource() datasource.DataSource {
+	return &ErrorDocumentsDataSource{}
+}
+
+// ErrorDocumentsDataSource defines the data source implementation.
+type ErrorDocumentsDataSource struct {
+	client assetsv1.Client
+}
+
+// ErrorDocumentsDataSourceModel describes the data source data model.
+type ErrorDocumentsDataSourceModel struct {
+	Namespace types.String                          `tfsdk:"namespace"`
+	Items     []assetsv1.ErrorDocumentResourceModel `tfsdk:"items"`
+}
+
+func (d *ErrorDocumentsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_error_documents"
+}
+
+func (d *ErrorDocumentsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "ErrorDocuments data source, lists the error documents of a namespace",
+
+		Attributes: listDataSourceAttributes(ctx, NewErrorDocumentResource()),
+	}
+}
+
+func (d *ErrorDocumentsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(assetsv1.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *assetsv1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ErrorDocumentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "Reading ErrorDocuments")
+
+	// Read Terraform configuration data into the model
+	var state ErrorDocumentsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	errorDocuments, err := d.client.ErrorDocument().List(ctx, &metav1.ListOptions{
+		Namespace: state.Namespace.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list error documents, got error: %s", err))
+		return
+	}
+
+	// generate state from protobuf resources
+	state.Items = make([]assetsv1.ErrorDocumentResourceModel, 0, len(errorDocuments.GetItems()))
+	for _, errorDocument := range errorDocuments.GetItems() {
+		var item assetsv1.ErrorDocumentResourceModel
+		if _, err := item.FromProto(errorDocument); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get state from error document %s/%s, got error: %s", errorDocument.GetMetadata().GetNamespace(), errorDocument.GetMetadata().GetName(), err))
+			return
+		}
+		state.Items = append(state.Items, item)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccErrorDocumentsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccErrorDocumentsDataSourceConfig("tf-acc-test", "tf-acc-tests"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ubika_error_documents.test", "namespace", "tf-acc-tests"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.ubika_error_documents.test", "items.*", map[string]string{
+						"metadata.name": "tf-acc-test",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccErrorDocumentsDataSourceConfig(name string, namespace string) string {
+	return testAccErrorDocumentResourceConfig(name, namespace) + fmt.Sprintf(`
+data "ubika_error_documents" "test" {
+  namespace = %[1]q
+
+  depends_on = [ubika_error_document.test]
+}
+`, namespace)
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccExceptionProfileDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccExceptionProfileDataSourceConfig("tf-acc-test", "tf-acc-tests"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ubika_exception_profile.test", "id", "tf-acc-tests/tf-acc-test"),
+					resource.TestCheckResourceAttrPair("data.ubika_exception_profile.test", "spec.rules.0.name", "ubika_exception_profile.test", "spec.rules.0.name"),
+				),
+			},
+		},
+	})
+}
+
+func testAccExceptionProfileDataSourceConfig(name string, namespace string) string {
+	return testAccExceptionProfileResourceConfig(name, namespace, "first-rule") + fmt.Sprintf(`
+data "ubika_exception_profile" "test" {
+  metadata = {
+    name = ubika_exception_profile.test.metadata.name
+    namespace = %[1]q
+  }
+}
+`, namespace)
+}
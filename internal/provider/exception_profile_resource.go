@@ -0,0 +1,372 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ExceptionProfileResource{}
+var _ resource.ResourceWithImportState = &ExceptionProfileResource{}
+
+func NewExceptionProfileResource() resource.Resource {
+	return &ExceptionProfileResource{}
+}
+
+// ExceptionProfileResource defines the resource implementation.
+type ExceptionProfileResource struct {
+	client assetsv1.Client
+}
+
+// exceptionProfileResourceModel is the state model of the resource. Unlike the
+// generated assetsv1.ExceptionProfileResourceModel, rules are kept as an
+// ordered list.
+type exceptionProfileResourceModel struct {
+	Id       string                             `tfsdk:"id"`
+	Metadata *metav1.ObjectMetaResourceModel    `tfsdk:"metadata"`
+	Spec     *exceptionProfileSpecResourceModel `tfsdk:"spec"`
+}
+
+type exceptionProfileSpecResourceModel struct {
+	Rules []assetsv1.ExceptionProfileSpec_RuleResourceModel `tfsdk:"rules"`
+}
+
+// FromProto imports field values from protobuf message
+func (m *exceptionProfileResourceModel) FromProto(r *assetsv1.ExceptionProfile) (_ *exceptionProfileResourceModel, err error) {
+	var generated assetsv1.ExceptionProfileResourceModel
+	if _, err = generated.FromProto(r); err != nil {
+		return m, err
+	}
+
+	m.Id = generated.Id
+	m.Metadata = generated.Metadata
+	m.Spec = &exceptionProfileSpecResourceModel{
+		Rules: make([]assetsv1.ExceptionProfileSpec_RuleResourceModel, 0, len(r.GetSpec().GetRules())),
+	}
+	for _, rule := range r.GetSpec().GetRules() {
+		m.Spec.Rules = append(m.Spec.Rules, assetsv1.ExceptionProfileSpec_RuleResourceModel{
+			Name:    rule.GetName(),
+			Filters: append([]string{}, rule.GetFilters()...),
+		})
+	}
+	return m, nil
+}
+
+// exceptionProfileResourceTFModel is the plan model of the resource.
+type exceptionProfileResourceTFModel struct {
+	Id       types.String `tfsdk:"id"`
+	Metadata types.Object `tfsdk:"metadata"`
+	Spec     types.Object `tfsdk:"spec"`
+}
+
+type exceptionProfileSpecResourceTFModel struct {
+	Rules types.List `tfsdk:"rules"`
+}
+
+// ToProto converts the model to the corresponding protobuf struct
+func (m *exceptionProfileResourceTFModel) ToProto(ctx context.Context) (*assetsv1.ExceptionProfile, diag.Diagnostics) {
+	r := assetsv1.NewExceptionProfile("")
+
+	var metadata *metav1.ObjectMetaResourceTFModel
+	if diags := m.Metadata.As(ctx, &metadata, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true}); diags.HasError() {
+		return r, diags
+	}
+	if MetadataTmp, diags := metadata.ToProto(ctx); diags.HasError() {
+		return r, diags
+	} else {
+		r.Metadata = MetadataTmp
+	}
+
+	var spec *exceptionProfileSpecResourceTFModel
+	if diags := m.Spec.As(ctx, &spec, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true}); diags.HasError() {
+		return r, diags
+	}
+	if spec == nil || spec.Rules.IsNull() || spec.Rules.IsUnknown() {
+		return r, nil
+	}
+
+	var rules []*assetsv1.ExceptionProfileSpec_RuleResourceTFModel
+	if diags := spec.Rules.ElementsAs(ctx, &rules, false); diags.HasError() {
+		return r, diags
+	}
+	for _, rule := range rules {
+		ruleTmp, diags := rule.ToProto(ctx)
+		if diags.HasError() {
+			return r, diags
+		}
+		r.Spec.Rules = append(r.Spec.Rules, ruleTmp)
+	}
+	return r, nil
+}
+
+func (r *ExceptionProfileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_exception_profile"
+}
+
+func (r *ExceptionProfileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "ExceptionProfile resource",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of this resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"metadata": GetObjectMetaResource(),
+			"spec": schema.SingleNestedAttribute{
+				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"rules": schema.ListNestedAttribute{
+						MarkdownDescription: "Ordered list of exception rules",
+						Required:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"name": schema.StringAttribute{
+									MarkdownDescription: "Name of the rule",
+									Required:            true,
+								},
+								"filters": schema.SetAttribute{
+									MarkdownDescription: "Filters matching the requests to exclude",
+									Required:            true,
+									ElementType:         types.StringType,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ExceptionProfileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(assetsv1.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *assetsv1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ExceptionProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "Creating ExceptionProfile")
+
+	// Read Terraform plan data into the model
+	var plan *exceptionProfileResourceTFModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// convert plan to protobuf resource
+	exceptionProfile, diags := plan.ToProto(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the resource
+	exceptionProfile, err := r.client.ExceptionProfile().Create(ctx, exceptionProfile)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create exception profile, got error: %s", err))
+		return
+	}
+
+	// generate state from protobuf resource
+	var state exceptionProfileResourceModel
+	_, err = state.FromProto(exceptionProfile)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get state from exception profile, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created an exception profile")
+
+	// Save state data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *ExceptionProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading ExceptionProfile")
+
+	// Read Terraform prior state data into the model
+	var state *exceptionProfileResourceTFModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	newState, diags := r.read(ctx, state.Metadata, nil)
+	if isNotFoundDiagnostics(diags) {
+		tflog.Warn(ctx, "Exception profile not found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *ExceptionProfileResource) read(ctx context.Context, metaObjValue basetypes.ObjectValue, meta *metav1.ObjectMetaResourceTFModel) (exceptionProfileResourceModel, diag.Diagnostics) {
+	// get metadata from state
+	if meta == nil {
+		if diags := metaObjValue.As(ctx, &meta, basetypes.ObjectAsOptions{}); diags.HasError() {
+			return exceptionProfileResourceModel{}, diags
+		}
+	}
+
+	exceptionProfile, err := r.client.ExceptionProfile().Get(ctx, &metav1.GetOptions{
+		Name:      meta.Name.ValueString(),
+		Namespace: meta.Namespace.ValueString(),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return exceptionProfileResourceModel{}, []diag.Diagnostic{notFoundDiagnostic("exception profile", meta)}
+		}
+		return exceptionProfileResourceModel{}, []diag.Diagnostic{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to read exception profile %s/%s, got error: %s", meta.Name.ValueString(), meta.Namespace.ValueString(), err))}
+	}
+
+	// update state from protobuf resource
+	var state exceptionProfileResourceModel
+	_, err = state.FromProto(exceptionProfile)
+	if err != nil {
+		return exceptionProfileResourceModel{}, []diag.Diagnostic{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to get state from exception profile %s/%s, got error: %s", meta.Name.ValueString(), meta.Namespace.ValueString(), err))}
+	}
+	return state, nil
+}
+
+func (r *ExceptionProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var plan *exceptionProfileResourceTFModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// convert plan to protobuf resource
+	exceptionProfile, diags := plan.ToProto(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// send the prior version so that concurrent changes are detected
+	priorMeta := exceptionProfile.GetMetadata()
+	resp.Diagnostics.Append(setPriorVersion(ctx, r.client, req.State, priorMeta)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only the changed fields are sent when partial updates are supported
+	svc := r.client.ExceptionProfile()
+	exceptionProfile, err := updateObject(ctx, r.client, svc, req, exceptionProfile, svc.Update)
+	if err != nil {
+		if isConflict(err, priorMeta.GetVersion() != 0) {
+			resp.Diagnostics.Append(conflictDiagnostic("exception profile", priorMeta))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update exception profile, got error: %s", err))
+		return
+	}
+
+	// generate state from protobuf resource
+	var state exceptionProfileResourceModel
+	_, err = state.FromProto(exceptionProfile)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get state from exception profile, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ExceptionProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "Deleting ExceptionProfile")
+	var plan *exceptionProfileResourceTFModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var meta metav1.ObjectMetaResourceTFModel
+	if diags := plan.Metadata.As(ctx, &meta, basetypes.ObjectAsOptions{}); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	_, err := r.client.ExceptionProfile().Delete(ctx, &metav1.DeleteOptions{
+		Name:      meta.Name.ValueString(),
+		Namespace: meta.Namespace.ValueString(),
+	})
+	if isNotFound(err) {
+		// already deleted
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete exception profile, got error: %s", err))
+		return
+	}
+}
+
+func (r *ExceptionProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	var name, namespace string
+	if len(parts) == 2 {
+		namespace = parts[0]
+		name = parts[1]
+	} else {
+		resp.Diagnostics.AddError("Inexpected input", "A namespace is required, ID must be in the form 'namespace/resource-name'")
+	}
+
+	meta := metav1.ObjectMetaResourceTFModel{
+		Name:      types.StringValue(name),
+		Namespace: types.StringValue(namespace),
+	}
+	state, diags := r.read(ctx, basetypes.ObjectValue{}, &meta)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
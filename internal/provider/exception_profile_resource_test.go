@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccExceptionProfileResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccExceptionProfileResourceConfig("tf-acc-test", "tf-acc-tests", "first-rule"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ubika_exception_profile.test", "metadata.name", "tf-acc-test"),
+					resource.TestCheckResourceAttr("ubika_exception_profile.test", "spec.rules.#", "2"),
+					resource.TestCheckResourceAttr("ubika_exception_profile.test", "spec.rules.0.name", "first-rule"),
+					resource.TestCheckResourceAttr("ubika_exception_profile.test", "spec.rules.1.name", "second-rule"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "ubika_exception_profile.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.namespace", "defaulted"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccExceptionProfileResourceConfig("tf-acc-test", "tf-acc-tests", "renamed-rule"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ubika_exception_profile.test", "metadata.namespace", "tf-acc-tests"),
+					resource.TestCheckResourceAttr("ubika_exception_profile.test", "spec.rules.0.name", "renamed-rule"),
+				),
+			},
+			// // Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccExceptionProfileResourceConfig(name string, namespace string, firstRule string) string {
+	return fmt.Sprintf(`
+resource "ubika_exception_profile" "test" {
+  metadata = {
+    name = %[1]q
+    namespace = %[2]q
+  }
+  spec = {
+	rules = [
+	  {
+		name    = %[3]q
+		filters = ["path == '/health'"]
+	  },
+	  {
+		name    = "second-rule"
+		filters = ["path == '/metrics'", "method == 'GET'"]
+	  },
+	]
+  }
+}
+`, name, namespace, firstRule)
+}
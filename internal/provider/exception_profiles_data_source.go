@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ExceptionProfilesDataSource{}
+
+func NewExceptionProfilesDataSource() datasource.DataSource {
+	return &ExceptionProfilesDataSource{}
+}
+
+// ExceptionProfilesDataSource defines the data source implementation.
+type ExceptionProfilesDataSource struct {
+	client assetsv1.Client
+}
+
+// ExceptionProfilesDataSourceModel describes the data source data model.
+type ExceptionProfilesDataSourceModel struct {
+	Namespace types.String                    `tfsdk:"namespace"`
+	Items     []exceptionProfileResourceModel `tfsdk:"items"`
+}
+
+func (d *ExceptionProfilesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_exception_profiles"
+}
+
+func (d *ExceptionProfilesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "ExceptionProfiles data source, lists the exception profiles of a namespace",
+
+		Attributes: listDataSourceAttributes(ctx, NewExceptionProfileResource()),
+	}
+}
+
+func (d *ExceptionProfilesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(assetsv1.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *assetsv1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ExceptionProfilesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "Reading ExceptionProfiles")
+
+	// Read Terraform configuration data into the model
+	var state ExceptionProfilesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exceptionProfiles, err := d.client.ExceptionProfile().List(ctx, &metav1.ListOptions{
+		Namespace: state.Namespace.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list exception profiles, got error: %s", err))
+		return
+	}
+
+	// generate state from protobuf resources
+	state.Items = make([]exceptionProfileResourceModel, 0, len(exceptionProfiles.GetItems()))
+	for _, exceptionProfile := range exceptionProfiles.GetItems() {
+		var item exceptionProfileResourceModel
+		if _, err := item.FromProto(exceptionProfile); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get state from exception profile %s/%s, got error: %s", exceptionProfile.GetMetadata().GetNamespace(), exceptionProfile.GetMetadata().GetName(), err))
+			return
+		}
+		state.Items = append(state.Items, item)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
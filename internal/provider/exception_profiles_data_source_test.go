@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccExceptionProfilesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccExceptionProfilesDataSourceConfig("tf-acc-test", "tf-acc-tests"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ubika_exception_profiles.test", "namespace", "tf-acc-tests"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.ubika_exception_profiles.test", "items.*", map[string]string{
+						"metadata.name": "tf-acc-test",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccExceptionProfilesDataSourceConfig(name string, namespace string) string {
+	return testAccExceptionProfileResourceConfig(name, namespace, "first-rule") + fmt.Sprintf(`
+data "ubika_exception_profiles" "test" {
+  namespace = %[1]q
+
+  depends_on = [ubika_exception_profile.test]
+}
+`, namespace)
+}
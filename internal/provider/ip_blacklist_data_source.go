@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &IPBlacklistDataSource{}
+
+func NewIPBlacklistDataSource() datasource.DataSource {
+	return &IPBlacklistDataSource{}
+}
+
+// IPBlacklistDataSource defines the data source implementation.
+type IPBlacklistDataSource struct {
+	client assetsv1.Client
+}
+
+func (d *IPBlacklistDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip_blacklist"
+}
+
+func (d *IPBlacklistDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "IPBlacklist data source",
+
+		Attributes: lookupDataSourceAttributes(ctx, NewIPBlacklistResource()),
+	}
+}
+
+func (d *IPBlacklistDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(assetsv1.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *assetsv1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *IPBlacklistDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "Reading IPBlacklist")
+
+	// Read Terraform configuration data into the model
+	var config *assetsv1.IPBlacklistResourceTFModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var meta metav1.ObjectMetaResourceTFModel
+	resp.Diagnostics.Append(config.Metadata.As(ctx, &meta, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ipBlacklist, err := d.client.IPBlacklist().Get(ctx, &metav1.GetOptions{
+		Name:      meta.Name.ValueString(),
+		Namespace: meta.Namespace.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read IP blacklist %s/%s, got error: %s", meta.Namespace.ValueString(), meta.Name.ValueString(), err))
+		return
+	}
+
+	// generate state from protobuf resource
+	var state assetsv1.IPBlacklistResourceModel
+	_, err = state.FromProto(ipBlacklist)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get state from IP blacklist %s/%s, got error: %s", meta.Namespace.ValueString(), meta.Name.ValueString(), err))
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
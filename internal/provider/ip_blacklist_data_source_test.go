@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccIPBlacklistDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccIPBlacklistDataSourceConfig("tf-acc-test", "tf-acc-tests"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ubika_ip_blacklist.test", "id", "tf-acc-tests/tf-acc-test"),
+					resource.TestCheckResourceAttrPair("data.ubika_ip_blacklist.test", "spec.ip_addresses.#", "ubika_ip_blacklist.test", "spec.ip_addresses.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccIPBlacklistDataSourceConfig(name string, namespace string) string {
+	return testAccIPBlacklistResourceConfig(name, namespace, "10.0.0.0/24") + fmt.Sprintf(`
+data "ubika_ip_blacklist" "test" {
+  metadata = {
+    name = ubika_ip_blacklist.test.metadata.name
+    namespace = %[1]q
+  }
+}
+`, namespace)
+}
@@ -0,0 +1,338 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &IPBlacklistResource{}
+var _ resource.ResourceWithImportState = &IPBlacklistResource{}
+
+func NewIPBlacklistResource() resource.Resource {
+	return &IPBlacklistResource{}
+}
+
+// IPBlacklistResource defines the resource implementation.
+type IPBlacklistResource struct {
+	client assetsv1.Client
+}
+
+func (r *IPBlacklistResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip_blacklist"
+}
+
+func (r *IPBlacklistResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "IPBlacklist resource",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of this resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"metadata": GetObjectMetaResource(),
+			"spec": schema.SingleNestedAttribute{
+				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"ip_addresses": schema.SetAttribute{
+						MarkdownDescription: "IPv4/IPv6 addresses and CIDRs to block. Entries are normalized before being sent (e.g. `10.0.0.1/24` to `10.0.0.0/24`), entries with the same normalized form are considered duplicates.",
+						Required:            true,
+						ElementType:         types.StringType,
+						Validators: []validator.Set{
+							ipAddressesValidator{},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *IPBlacklistResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(assetsv1.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *assetsv1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *IPBlacklistResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "Creating IPBlacklist")
+
+	// Read Terraform plan data into the model
+	var plan *assetsv1.IPBlacklistResourceTFModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// convert plan to protobuf resource
+	ipBlacklist, diags := newIPBlacklist(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the resource
+	ipBlacklist, err := r.client.IPBlacklist().Create(ctx, ipBlacklist)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create IP blacklist, got error: %s", err))
+		return
+	}
+
+	// generate state from protobuf resource
+	state, diags := newIPBlacklistState(ctx, ipBlacklist, plan.Spec)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created an IP blacklist")
+
+	// Save state data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *IPBlacklistResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading IPBlacklist")
+
+	// Read Terraform prior state data into the model
+	var state *assetsv1.IPBlacklistResourceTFModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	newState, diags := r.read(ctx, state.Metadata, nil, state.Spec)
+	if isNotFoundDiagnostics(diags) {
+		tflog.Warn(ctx, "IP blacklist not found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *IPBlacklistResource) read(ctx context.Context, metaObjValue basetypes.ObjectValue, meta *metav1.ObjectMetaResourceTFModel, prior basetypes.ObjectValue) (assetsv1.IPBlacklistResourceModel, diag.Diagnostics) {
+	// get metadata from state
+	if meta == nil {
+		if diags := metaObjValue.As(ctx, &meta, basetypes.ObjectAsOptions{}); diags.HasError() {
+			return assetsv1.IPBlacklistResourceModel{}, diags
+		}
+	}
+
+	ipBlacklist, err := r.client.IPBlacklist().Get(ctx, &metav1.GetOptions{
+		Name:      meta.Name.ValueString(),
+		Namespace: meta.Namespace.ValueString(),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return assetsv1.IPBlacklistResourceModel{}, []diag.Diagnostic{notFoundDiagnostic("IP blacklist", meta)}
+		}
+		return assetsv1.IPBlacklistResourceModel{}, []diag.Diagnostic{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to read IP blacklist %s/%s, got error: %s", meta.Name.ValueString(), meta.Namespace.ValueString(), err))}
+	}
+
+	// update state from protobuf resource
+	return newIPBlacklistState(ctx, ipBlacklist, prior)
+}
+
+func (r *IPBlacklistResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var plan *assetsv1.IPBlacklistResourceTFModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// convert plan to protobuf resource
+	ipBlacklist, diags := newIPBlacklist(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// send the prior version so that concurrent changes are detected
+	priorMeta := ipBlacklist.GetMetadata()
+	resp.Diagnostics.Append(setPriorVersion(ctx, r.client, req.State, priorMeta)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only the changed fields are sent when partial updates are supported
+	svc := r.client.IPBlacklist()
+	ipBlacklist, err := updateObject(ctx, r.client, svc, req, ipBlacklist, svc.Update)
+	if err != nil {
+		if isConflict(err, priorMeta.GetVersion() != 0) {
+			resp.Diagnostics.Append(conflictDiagnostic("IP blacklist", priorMeta))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update IP blacklist, got error: %s", err))
+		return
+	}
+
+	// generate state from protobuf resource
+	state, diags := newIPBlacklistState(ctx, ipBlacklist, plan.Spec)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *IPBlacklistResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "Deleting IPBlacklist")
+	var plan *assetsv1.IPBlacklistResourceTFModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var meta metav1.ObjectMetaResourceTFModel
+	if diags := plan.Metadata.As(ctx, &meta, basetypes.ObjectAsOptions{}); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	_, err := r.client.IPBlacklist().Delete(ctx, &metav1.DeleteOptions{
+		Name:      meta.Name.ValueString(),
+		Namespace: meta.Namespace.ValueString(),
+	})
+	if isNotFound(err) {
+		// already deleted
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete IP blacklist, got error: %s", err))
+		return
+	}
+}
+
+func (r *IPBlacklistResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	var name, namespace string
+	if len(parts) == 2 {
+		namespace = parts[0]
+		name = parts[1]
+	} else {
+		resp.Diagnostics.AddError("Inexpected input", "A namespace is required, ID must be in the form 'namespace/resource-name'")
+	}
+
+	meta := metav1.ObjectMetaResourceTFModel{
+		Name:      types.StringValue(name),
+		Namespace: types.StringValue(namespace),
+	}
+	state, diags := r.read(ctx, basetypes.ObjectValue{}, &meta, types.ObjectNull(nil))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// newIPBlacklist converts the plan to the protobuf resource with normalized,
+// deduplicated and sorted IP addresses.
+func newIPBlacklist(ctx context.Context, plan *assetsv1.IPBlacklistResourceTFModel) (*assetsv1.IPBlacklist, diag.Diagnostics) {
+	ipBlacklist, diags := plan.ToProto(ctx)
+	if diags.HasError() {
+		return ipBlacklist, diags
+	}
+
+	ipAddresses, err := normalizeIPAddresses(ipBlacklist.GetSpec().GetIpAddresses())
+	if err != nil {
+		diags.AddAttributeError(path.Root("spec").AtName("ip_addresses"), "Invalid Attribute Value", fmt.Sprintf("Unable to normalize IP addresses, got error: %s", err))
+		return ipBlacklist, diags
+	}
+	ipBlacklist.Spec.IpAddresses = ipAddresses
+	return ipBlacklist, diags
+}
+
+// newIPBlacklistState generates the state from the protobuf resource. The IP
+// addresses of the prior spec are kept when they only differ from the ones
+// returned by the API by their form, order or duplicates.
+func newIPBlacklistState(ctx context.Context, ipBlacklist *assetsv1.IPBlacklist, prior basetypes.ObjectValue) (assetsv1.IPBlacklistResourceModel, diag.Diagnostics) {
+	var state assetsv1.IPBlacklistResourceModel
+	if _, err := state.FromProto(ipBlacklist); err != nil {
+		return assetsv1.IPBlacklistResourceModel{}, []diag.Diagnostic{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to get state from IP blacklist, got error: %s", err))}
+	}
+	if state.Spec == nil {
+		state.Spec = &assetsv1.IPBlacklistSpecResourceModel{}
+	}
+	if state.Spec.IpAddresses == nil {
+		state.Spec.IpAddresses = []string{}
+	}
+
+	if prior.IsNull() || prior.IsUnknown() {
+		return state, nil
+	}
+
+	var priorSpec assetsv1.IPBlacklistSpecResourceTFModel
+	if diags := prior.As(ctx, &priorSpec, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return assetsv1.IPBlacklistResourceModel{}, diags
+	}
+	var priorIPAddresses []string
+	if diags := priorSpec.IpAddresses.ElementsAs(ctx, &priorIPAddresses, false); diags.HasError() {
+		return assetsv1.IPBlacklistResourceModel{}, diags
+	}
+
+	wanted, err := normalizeIPAddresses(priorIPAddresses)
+	if err != nil {
+		return state, nil
+	}
+	got, err := normalizeIPAddresses(state.Spec.IpAddresses)
+	if err != nil {
+		return state, nil
+	}
+	if reflect.DeepEqual(wanted, got) {
+		state.Spec.IpAddresses = priorIPAddresses
+	}
+
+	return state, nil
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+)
+
+func TestAccIPBlacklistResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccIPBlacklistResourceConfig("tf-acc-test", "tf-acc-tests", "10.0.0.1/24", "192.168.0.1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ubika_ip_blacklist.test", "metadata.name", "tf-acc-test"),
+					resource.TestCheckTypeSetElemAttr("ubika_ip_blacklist.test", "spec.ip_addresses.*", "10.0.0.1/24"),
+				),
+			},
+			// Reordering and duplicates do not produce a diff
+			{
+				Config:   testAccIPBlacklistResourceConfig("tf-acc-test", "tf-acc-tests", "192.168.0.1", "10.0.0.1/24", "192.168.0.1"),
+				PlanOnly: true,
+			},
+			// ImportState testing
+			{
+				ResourceName:            "ubika_ip_blacklist.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.namespace", "defaulted", "spec.ip_addresses"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccIPBlacklistResourceConfig("tf-acc-test", "tf-acc-tests", "2001:db8::/32"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ubika_ip_blacklist.test", "metadata.namespace", "tf-acc-tests"),
+					resource.TestCheckResourceAttr("ubika_ip_blacklist.test", "spec.ip_addresses.#", "1"),
+				),
+			},
+			// // Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestNewIPBlacklistState(t *testing.T) {
+	ctx := context.Background()
+
+	ipBlacklist := assetsv1.NewIPBlacklist("test")
+	ipBlacklist.Spec.IpAddresses = []string{"10.0.0.0/24", "192.168.0.1"}
+
+	testCases := []struct {
+		name  string
+		prior []string
+		want  []string
+	}{
+		{"same", []string{"10.0.0.0/24", "192.168.0.1"}, []string{"10.0.0.0/24", "192.168.0.1"}},
+		{"not normalized", []string{"192.168.0.1", "10.0.0.1/24"}, []string{"192.168.0.1", "10.0.0.1/24"}},
+		{"duplicates", []string{"192.168.0.1/32", "10.0.0.1/24", "10.0.0.0/24"}, []string{"192.168.0.1/32", "10.0.0.1/24", "10.0.0.0/24"}},
+		{"changed", []string{"10.0.0.1"}, []string{"10.0.0.0/24", "192.168.0.1"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			elements := make([]attr.Value, 0, len(tc.prior))
+			for _, address := range tc.prior {
+				elements = append(elements, types.StringValue(address))
+			}
+			prior := types.ObjectValueMust(
+				map[string]attr.Type{"ip_addresses": types.SetType{ElemType: types.StringType}},
+				map[string]attr.Value{"ip_addresses": types.SetValueMust(types.StringType, elements)},
+			)
+
+			state, diags := newIPBlacklistState(ctx, ipBlacklist, prior)
+			assert.False(t, diags.HasError())
+			assert.ElementsMatch(t, tc.want, state.Spec.IpAddresses)
+		})
+	}
+}
+
+func testAccIPBlacklistResourceConfig(name string, namespace string, ipAddresses ...string) string {
+	return fmt.Sprintf(`
+resource "ubika_ip_blacklist" "test" {
+  metadata = {
+    name = %[1]q
+    namespace = %[2]q
+  }
+  spec = {
+	ip_addresses = ["%[3]s"]
+  }
+}
+`, name, namespace, strings.Join(ipAddresses, `", "`))
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &IPBlacklistsDataSource{}
+
+func NewIPBlacklistsDataSource() datasource.DataSource {
+	return &IPBlacklistsDataSource{}
+}
+
+// IPBlacklistsDataSource defines the data source implementation.
+type IPBlacklistsDataSource struct {
+	client assetsv1.Client
+}
+
+// IPBlacklistsDataSourceModel describes the data source data model.
+type IPBlacklistsDataSourceModel struct {
+	Namespace types.String                        `tfsdk:"namespace"`
+	Items     []assetsv1.IPBlacklistResourceModel `tfsdk:"items"`
+}
+
+func (d *IPBlacklistsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip_blacklists"
+}
+
+func (d *IPBlacklistsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "IPBlacklists data source, lists the IP blacklists of a namespace",
+
+		Attributes: listDataSourceAttributes(ctx, NewIPBlacklistResource()),
+	}
+}
+
+func (d *IPBlacklistsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(assetsv1.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *assetsv1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *IPBlacklistsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "Reading IPBlacklists")
+
+	// Read Terraform configuration data into the model
+	var state IPBlacklistsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ipBlacklists, err := d.client.IPBlacklist().List(ctx, &metav1.ListOptions{
+		Namespace: state.Namespace.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list IP blacklists, got error: %s", err))
+		return
+	}
+
+	// generate state from protobuf resources
+	state.Items = make([]assetsv1.IPBlacklistResourceModel, 0, len(ipBlacklists.GetItems()))
+	for _, ipBlacklist := range ipBlacklists.GetItems() {
+		var item assetsv1.IPBlacklistResourceModel
+		if _, err := item.FromProto(ipBlacklist); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get state from IP blacklist %s/%s, got error: %s", ipBlacklist.GetMetadata().GetNamespace(), ipBlacklist.GetMetadata().GetName(), err))
+			return
+		}
+		state.Items = append(state.Items, item)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
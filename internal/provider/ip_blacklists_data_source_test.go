@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccIPBlacklistsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccIPBlacklistsDataSourceConfig("tf-acc-test", "tf-acc-tests"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ubika_ip_blacklists.test", "namespace", "tf-acc-tests"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.ubika_ip_blacklists.test", "items.*", map[string]string{
+						"metadata.name": "tf-acc-test",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccIPBlacklistsDataSourceConfig(name string, namespace string) string {
+	return testAccIPBlacklistResourceConfig(name, namespace, "10.0.0.0/24") + fmt.Sprintf(`
+data "ubika_ip_blacklists" "test" {
+  namespace = %[1]q
+
+  depends_on = [ubika_ip_blacklist.test]
+}
+`, namespace)
+}
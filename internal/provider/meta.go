@@ -1,6 +1,7 @@
 package provider
 
 import (
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -36,3 +37,30 @@ func GetObjectMetaResource() schema.Attribute {
 		},
 	}
 }
+
+// GetObjectMetaDataSource returns the metadata of a data source looking up a
+// single resource by name and namespace.
+func GetObjectMetaDataSource() dsschema.Attribute {
+	return dsschema.SingleNestedAttribute{
+		Required: true,
+		Attributes: map[string]dsschema.Attribute{
+			"name": dsschema.StringAttribute{
+				MarkdownDescription: "Name of the resource",
+				Required:            true,
+			},
+			"namespace": dsschema.StringAttribute{
+				MarkdownDescription: "Namespace of the resource",
+				Required:            true,
+			},
+			"created": dsschema.Int64Attribute{
+				Computed: true,
+			},
+			"updated": dsschema.Int64Attribute{
+				Computed: true,
+			},
+			"version": dsschema.Int64Attribute{
+				Computed: true,
+			},
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// notFoundSummary is the summary of the diagnostics reporting a resource that
+// does not exist.
+const notFoundSummary = "Resource Not Found"
+
+// isNotFound returns true if err is a gRPC NotFound error.
+func isNotFound(err error) bool {
+	return status.Code(err) == codes.NotFound
+}
+
+// notFoundDiagnostic reports that a resource does not exist.
+func notFoundDiagnostic(kind string, meta *metav1.ObjectMetaResourceTFModel) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(notFoundSummary, fmt.Sprintf("The %s %s/%s does not exist.", kind, meta.Namespace.ValueString(), meta.Name.ValueString()))
+}
+
+// isNotFoundDiagnostics returns true if diags report a resource that does not
+// exist, Read then removes it from the state so that it is recreated.
+func isNotFoundDiagnostics(diags diag.Diagnostics) bool {
+	for _, d := range diags.Errors() {
+		if d.Summary() == notFoundSummary {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeClient is an assetsv1.Client only implementing the asset service.
+type fakeClient struct {
+	assetsv1.Client
+	asset *fakeAssetSvcClient
+}
+
+func (c *fakeClient) Asset() assetsv1.AssetSvcClient {
+	return c.asset
+}
+
+// fakeAssetSvcClient is an assetsv1.AssetSvcClient storing a single asset.
+// Updates of another version of the asset are rejected, as by the API, with
+// conflictErr or Aborted when not set. All updates fail with updateErr when
+// set.
+type fakeAssetSvcClient struct {
+	assetsv1.AssetSvcClient
+	asset       *assetsv1.Asset
+	conflictErr error
+	updateErr   error
+}
+
+func (c *fakeAssetSvcClient) Get(ctx context.Context, in *metav1.GetOptions, opts ...grpc.CallOption) (*assetsv1.Asset, error) {
+	if c.asset == nil {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	return c.asset, nil
+}
+
+func (c *fakeAssetSvcClient) Update(ctx context.Context, in *assetsv1.Asset, opts ...grpc.CallOption) (*assetsv1.Asset, error) {
+	if c.asset == nil {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	if c.updateErr != nil {
+		return nil, c.updateErr
+	}
+	if version := in.GetMetadata().GetVersion(); version != 0 && version != c.asset.GetMetadata().GetVersion() {
+		if c.conflictErr != nil {
+			return nil, c.conflictErr
+		}
+		return nil, status.Error(codes.Aborted, "version conflict")
+	}
+	asset := proto.Clone(in).(*assetsv1.Asset)
+	asset.Metadata.Version = c.asset.GetMetadata().GetVersion() + 1
+	c.asset = asset
+	return asset, nil
+}
+
+func (c *fakeAssetSvcClient) Delete(ctx context.Context, in *metav1.DeleteOptions, opts ...grpc.CallOption) (*assetsv1.Asset, error) {
+	if c.asset == nil {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	asset := c.asset
+	c.asset = nil
+	return asset, nil
+}
+
+// testAssetState returns the state of an asset.
+func testAssetState(t *testing.T, asset *assetsv1.Asset) tfsdk.State {
+	ctx := context.Background()
+
+	var schemaResp fwresource.SchemaResponse
+	NewAssetResource().Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	var timeoutsValue timeouts.Value
+	diags := state.GetAttribute(ctx, path.Root("timeouts"), &timeoutsValue)
+	require.False(t, diags.HasError(), diags)
+
+	model, diags := newAssetState(ctx, asset, types.ObjectNull(nil), nil, timeoutsValue)
+	require.False(t, diags.HasError(), diags)
+	diags = state.Set(ctx, &model)
+	require.False(t, diags.HasError(), diags)
+	return state
+}
+
+func TestIsNotFound(t *testing.T) {
+	assert.True(t, isNotFound(status.Error(codes.NotFound, "not found")))
+	assert.False(t, isNotFound(status.Error(codes.Unavailable, "unavailable")))
+	assert.False(t, isNotFound(errors.New("not found")))
+	assert.False(t, isNotFound(nil))
+}
+
+func TestAssetResourceNotFound(t *testing.T) {
+	ctx := context.Background()
+
+	asset := assetsv1.NewAsset("tf-acc-test")
+	asset.Metadata.Namespace = "tf-acc-tests"
+	client := &fakeClient{asset: &fakeAssetSvcClient{}}
+	r := &AssetResource{client: client}
+
+	// read removes the deleted asset from the state
+	readResp := fwresource.ReadResponse{State: testAssetState(t, asset)}
+	r.Read(ctx, fwresource.ReadRequest{State: testAssetState(t, asset)}, &readResp)
+	assert.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	assert.True(t, readResp.State.Raw.IsNull())
+
+	// delete succeeds
+	deleteResp := fwresource.DeleteResponse{State: testAssetState(t, asset)}
+	r.Delete(ctx, fwresource.DeleteRequest{State: testAssetState(t, asset)}, &deleteResp)
+	assert.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+
+	// import reports the asset does not exist
+	importResp := fwresource.ImportStateResponse{State: tfsdk.State{Schema: readResp.State.Schema, Raw: tftypes.NewValue(readResp.State.Schema.Type().TerraformType(ctx), nil)}}
+	r.ImportState(ctx, fwresource.ImportStateRequest{ID: "tf-acc-tests/tf-acc-test"}, &importResp)
+	require.True(t, importResp.Diagnostics.HasError())
+	assert.Equal(t, "The asset tf-acc-tests/tf-acc-test does not exist.", importResp.Diagnostics.Errors()[0].Detail())
+
+	// an existing asset is kept
+	client.asset.asset = asset
+	readResp = fwresource.ReadResponse{State: testAssetState(t, asset)}
+	r.Read(ctx, fwresource.ReadRequest{State: testAssetState(t, asset)}, &readResp)
+	assert.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+	assert.False(t, readResp.State.Raw.IsNull())
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccOpenAPIDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccOpenAPIDataSourceConfig("tf-acc-test", "tf-acc-tests"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ubika_openapi.test", "id", "tf-acc-tests/tf-acc-test"),
+					resource.TestCheckResourceAttrPair("data.ubika_openapi.test", "spec.source", "ubika_openapi.test", "spec.source"),
+				),
+			},
+		},
+	})
+}
+
+func testAccOpenAPIDataSourceConfig(name string, namespace string) string {
+	return testAccOpenAPIResourceConfig(name, namespace, `{"openapi": "3.0.0", "info": {"title": "tf-acc-test", "version": "1.0.0"}, "paths": {}}`) + fmt.Sprintf(`
+data "ubika_openapi" "test" {
+  metadata = {
+    name = ubika_openapi.test.metadata.name
+    namespace = %[1]q
+  }
+}
+`, namespace)
+}
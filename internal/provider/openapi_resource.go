@@ -0,0 +1,389 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &OpenAPIResource{}
+var _ resource.ResourceWithImportState = &OpenAPIResource{}
+var _ resource.ResourceWithValidateConfig = &OpenAPIResource{}
+
+func NewOpenAPIResource() resource.Resource {
+	return &OpenAPIResource{}
+}
+
+// OpenAPIResource defines the resource implementation.
+type OpenAPIResource struct {
+	client assetsv1.Client
+}
+
+// openAPIResourceModel is the state model of the resource. It differs from the
+// generated assetsv1.OpenAPIResourceModel by the source_file attribute which
+// only exists on the Terraform side.
+type openAPIResourceModel struct {
+	Id       string                          `tfsdk:"id"`
+	Metadata *metav1.ObjectMetaResourceModel `tfsdk:"metadata"`
+	Spec     *openAPISpecResourceModel       `tfsdk:"spec"`
+}
+
+type openAPISpecResourceModel struct {
+	Source     string  `tfsdk:"source"`
+	SourceFile *string `tfsdk:"source_file"`
+}
+
+// openAPIResourceTFModel is the plan model of the resource.
+type openAPIResourceTFModel struct {
+	Id       types.String `tfsdk:"id"`
+	Metadata types.Object `tfsdk:"metadata"`
+	Spec     types.Object `tfsdk:"spec"`
+}
+
+type openAPISpecResourceTFModel struct {
+	Source     openAPISourceValue `tfsdk:"source"`
+	SourceFile types.String       `tfsdk:"source_file"`
+}
+
+// ToProto converts the model to the corresponding protobuf struct
+func (m *openAPIResourceTFModel) ToProto(ctx context.Context) (*assetsv1.OpenAPI, diag.Diagnostics) {
+	r := assetsv1.NewOpenAPI("")
+
+	var metadata *metav1.ObjectMetaResourceTFModel
+	if diags := m.Metadata.As(ctx, &metadata, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true}); diags.HasError() {
+		return r, diags
+	}
+	if MetadataTmp, diags := metadata.ToProto(ctx); diags.HasError() {
+		return r, diags
+	} else {
+		r.Metadata = MetadataTmp
+	}
+
+	var spec *openAPISpecResourceTFModel
+	if diags := m.Spec.As(ctx, &spec, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true}); diags.HasError() {
+		return r, diags
+	}
+	if spec != nil && !spec.Source.IsNull() && !spec.Source.IsUnknown() {
+		r.Spec.Source = spec.Source.ValueString()
+	}
+	return r, nil
+}
+
+func (r *OpenAPIResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_openapi"
+}
+
+func (r *OpenAPIResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "OpenAPI resource",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of this resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"metadata": GetObjectMetaResource(),
+			"spec": schema.SingleNestedAttribute{
+				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"source": schema.StringAttribute{
+						MarkdownDescription: "OpenAPI specification in JSON or YAML (at most 2 MiB). Conflicts with `source_file`.",
+						Optional:            true,
+						Computed:            true,
+						CustomType:          openAPISourceType{},
+						PlanModifiers: []planmodifier.String{
+							openAPISourcePlanModifier{},
+						},
+					},
+					"source_file": schema.StringAttribute{
+						MarkdownDescription: "Path to a local file containing the OpenAPI specification. Conflicts with `source`.",
+						Optional:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *OpenAPIResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(assetsv1.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *assetsv1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *OpenAPIResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var source openAPISourceValue
+	var sourceFile types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("spec").AtName("source"), &source)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("spec").AtName("source_file"), &sourceFile)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// values will be known later on
+	if source.IsUnknown() || sourceFile.IsUnknown() {
+		return
+	}
+
+	if source.IsNull() == sourceFile.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("spec"),
+			"Invalid Attribute Combination",
+			"Exactly one of spec.source or spec.source_file must be set.",
+		)
+	}
+}
+
+func (r *OpenAPIResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "Creating OpenAPI")
+
+	// Read Terraform plan data into the model
+	var plan *openAPIResourceTFModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// convert plan to protobuf resource
+	openAPI, diags := plan.ToProto(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the resource
+	openAPI, err := r.client.OpenAPI().Create(ctx, openAPI)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create openapi, got error: %s", err))
+		return
+	}
+
+	// generate state from protobuf resource
+	state, diags := newOpenAPIState(ctx, openAPI, plan.Spec)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created an openapi")
+
+	// Save state data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *OpenAPIResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading OpenAPI")
+
+	// Read Terraform prior state data into the model
+	var state *openAPIResourceTFModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	newState, diags := r.read(ctx, state.Metadata, nil, state.Spec)
+	if isNotFoundDiagnostics(diags) {
+		tflog.Warn(ctx, "Openapi not found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *OpenAPIResource) read(ctx context.Context, metaObjValue basetypes.ObjectValue, meta *metav1.ObjectMetaResourceTFModel, prior basetypes.ObjectValue) (openAPIResourceModel, diag.Diagnostics) {
+	// get metadata from state
+	if meta == nil {
+		if diags := metaObjValue.As(ctx, &meta, basetypes.ObjectAsOptions{}); diags.HasError() {
+			return openAPIResourceModel{}, diags
+		}
+	}
+
+	openAPI, err := r.client.OpenAPI().Get(ctx, &metav1.GetOptions{
+		Name:      meta.Name.ValueString(),
+		Namespace: meta.Namespace.ValueString(),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return openAPIResourceModel{}, []diag.Diagnostic{notFoundDiagnostic("openapi", meta)}
+		}
+		return openAPIResourceModel{}, []diag.Diagnostic{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to read openapi %s/%s, got error: %s", meta.Name.ValueString(), meta.Namespace.ValueString(), err))}
+	}
+
+	// update state from protobuf resource
+	return newOpenAPIState(ctx, openAPI, prior)
+}
+
+func (r *OpenAPIResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var plan *openAPIResourceTFModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// convert plan to protobuf resource
+	openAPI, diags := plan.ToProto(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// send the prior version so that concurrent changes are detected
+	priorMeta := openAPI.GetMetadata()
+	resp.Diagnostics.Append(setPriorVersion(ctx, r.client, req.State, priorMeta)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only the changed fields are sent when partial updates are supported
+	svc := r.client.OpenAPI()
+	openAPI, err := updateObject(ctx, r.client, svc, req, openAPI, svc.Update)
+	if err != nil {
+		if isConflict(err, priorMeta.GetVersion() != 0) {
+			resp.Diagnostics.Append(conflictDiagnostic("openapi", priorMeta))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update openapi, got error: %s", err))
+		return
+	}
+
+	// generate state from protobuf resource
+	state, diags := newOpenAPIState(ctx, openAPI, plan.Spec)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *OpenAPIResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "Deleting OpenAPI")
+	var plan *openAPIResourceTFModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var meta metav1.ObjectMetaResourceTFModel
+	if diags := plan.Metadata.As(ctx, &meta, basetypes.ObjectAsOptions{}); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	_, err := r.client.OpenAPI().Delete(ctx, &metav1.DeleteOptions{
+		Name:      meta.Name.ValueString(),
+		Namespace: meta.Namespace.ValueString(),
+	})
+	if isNotFound(err) {
+		// already deleted
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete openapi, got error: %s", err))
+		return
+	}
+}
+
+func (r *OpenAPIResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	var name, namespace string
+	if len(parts) == 2 {
+		namespace = parts[0]
+		name = parts[1]
+	} else {
+		resp.Diagnostics.AddError("Inexpected input", "A namespace is required, ID must be in the form 'namespace/resource-name'")
+	}
+
+	meta := metav1.ObjectMetaResourceTFModel{
+		Name:      types.StringValue(name),
+		Namespace: types.StringValue(namespace),
+	}
+	state, diags := r.read(ctx, basetypes.ObjectValue{}, &meta, types.ObjectNull(nil))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// newOpenAPIState generates the state from the protobuf resource. The
+// source_file of the prior spec is kept, a source returned by the API which
+// only differs by formatting is handled by the semantic equality of
+// openAPISourceType.
+func newOpenAPIState(ctx context.Context, openAPI *assetsv1.OpenAPI, prior basetypes.ObjectValue) (openAPIResourceModel, diag.Diagnostics) {
+	var generated assetsv1.OpenAPIResourceModel
+	if _, err := generated.FromProto(openAPI); err != nil {
+		return openAPIResourceModel{}, []diag.Diagnostic{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to get state from openapi, got error: %s", err))}
+	}
+
+	state := openAPIResourceModel{
+		Id:       generated.Id,
+		Metadata: generated.Metadata,
+		Spec:     &openAPISpecResourceModel{},
+	}
+	if generated.Spec != nil {
+		state.Spec.Source = generated.Spec.Source
+	}
+
+	if prior.IsNull() || prior.IsUnknown() {
+		return state, nil
+	}
+
+	var priorSpec openAPISpecResourceTFModel
+	if diags := prior.As(ctx, &priorSpec, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return openAPIResourceModel{}, diags
+	}
+	if !priorSpec.SourceFile.IsNull() {
+		state.Spec.SourceFile = priorSpec.SourceFile.ValueStringPointer()
+	}
+
+	return state, nil
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccOpenAPIResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccOpenAPIResourceConfig("tf-acc-test", "tf-acc-tests", `{"openapi": "3.0.0", "info": {"title": "tf-acc-test", "version": "1.0.0"}, "paths": {}}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ubika_openapi.test", "metadata.name", "tf-acc-test"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "ubika_openapi.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.namespace", "defaulted"},
+			},
+			// Reformatting the specification must not produce any change
+			{
+				Config:   testAccOpenAPIResourceConfig("tf-acc-test", "tf-acc-tests", "openapi: 3.0.0\npaths: {}\ninfo:\n  version: 1.0.0\n  title: tf-acc-test\n"),
+				PlanOnly: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccOpenAPIResourceConfig("tf-acc-test", "tf-acc-tests", `{"openapi": "3.0.0", "info": {"title": "tf-acc-test", "version": "2.0.0"}, "paths": {}}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ubika_openapi.test", "metadata.namespace", "tf-acc-tests"),
+				),
+			},
+			// // Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccOpenAPIResourceConfig(name string, namespace string, source string) string {
+	return fmt.Sprintf(`
+resource "ubika_openapi" "test" {
+  metadata = {
+    name = %[1]q
+    namespace = %[2]q
+  }
+  spec = {
+	source = %[3]q
+  }
+}
+`, name, namespace, source)
+}
+
+func TestOpenAPISourceEqual(t *testing.T) {
+	testCases := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{"identical", `{"openapi": "3.0.0"}`, `{"openapi": "3.0.0"}`, true},
+		{"json_whitespace", `{"openapi": "3.0.0", "paths": {}}`, "{\n  \"openapi\": \"3.0.0\",\n  \"paths\": {}\n}", true},
+		{"json_key_order", `{"openapi": "3.0.0", "paths": {}}`, `{"paths": {}, "openapi": "3.0.0"}`, true},
+		{"json_yaml", `{"openapi": "3.0.0", "info": {"title": "test"}}`, "info:\n  title: test\nopenapi: 3.0.0\n", true},
+		{"different_value", `{"openapi": "3.0.0"}`, `{"openapi": "3.1.0"}`, false},
+		{"invalid_document", `{"openapi": "3.0.0"`, `{"openapi": "3.0.0"}`, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, openAPISourceEqual(tc.a, tc.b))
+		})
+	}
+}
+
+func TestOpenAPISourcePlanModifier(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp fwresource.SchemaResponse
+	NewOpenAPIResource().Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	specType := objectType.AttributeTypes["spec"].(tftypes.Object)
+
+	const json = `{"openapi": "3.0.0", "info": {"title": "test"}}`
+	const yaml = "info:\n  title: test\nopenapi: 3.0.0\n"
+	file := filepath.Join(t.TempDir(), "openapi.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte(yaml), 0o600))
+
+	testCases := []struct {
+		name       string
+		source     tftypes.Value
+		sourceFile tftypes.Value
+		state      types.String
+		want       types.String
+	}{
+		{"create", tftypes.NewValue(tftypes.String, yaml), tftypes.NewValue(tftypes.String, nil), types.StringNull(), types.StringValue(yaml)},
+		{"inline_equivalent", tftypes.NewValue(tftypes.String, yaml), tftypes.NewValue(tftypes.String, nil), types.StringValue(json), types.StringValue(json)},
+		{"inline_changed", tftypes.NewValue(tftypes.String, `{"openapi": "3.1.0"}`), tftypes.NewValue(tftypes.String, nil), types.StringValue(json), types.StringValue(`{"openapi": "3.1.0"}`)},
+		{"file_equivalent", tftypes.NewValue(tftypes.String, nil), tftypes.NewValue(tftypes.String, file), types.StringValue(json), types.StringValue(json)},
+		{"file_unknown", tftypes.NewValue(tftypes.String, nil), tftypes.NewValue(tftypes.String, tftypes.UnknownValue), types.StringValue(json), types.StringUnknown()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := tftypes.NewValue(objectType, map[string]tftypes.Value{
+				"id":       tftypes.NewValue(tftypes.String, nil),
+				"metadata": tftypes.NewValue(objectType.AttributeTypes["metadata"], nil),
+				"spec": tftypes.NewValue(specType, map[string]tftypes.Value{
+					"source":      tc.source,
+					"source_file": tc.sourceFile,
+				}),
+			})
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: raw}
+
+			var validateResp fwresource.ValidateConfigResponse
+			NewOpenAPIResource().(fwresource.ResourceWithValidateConfig).ValidateConfig(ctx, fwresource.ValidateConfigRequest{Config: config}, &validateResp)
+			assert.False(t, validateResp.Diagnostics.HasError(), "%v", validateResp.Diagnostics)
+
+			var source openAPISourceValue
+			assert.False(t, config.GetAttribute(ctx, path.Root("spec").AtName("source"), &source).HasError())
+			configValue := source.StringValue
+			req := planmodifier.StringRequest{
+				Path:        path.Root("spec").AtName("source"),
+				Config:      config,
+				ConfigValue: configValue,
+				Plan:        tfsdk.Plan{Schema: schemaResp.Schema, Raw: raw},
+				PlanValue:   configValue,
+				StateValue:  tc.state,
+			}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+			openAPISourcePlanModifier{}.PlanModifyString(ctx, req, resp)
+			assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+			assert.Equal(t, tc.want, resp.PlanValue)
+
+			if !tc.state.IsNull() && !tc.want.IsUnknown() {
+				equal, diags := openAPISourceValue{StringValue: tc.state}.StringSemanticEquals(ctx, openAPISourceValue{StringValue: tc.want})
+				assert.False(t, diags.HasError())
+				assert.Equal(t, tc.name != "inline_changed", equal)
+			}
+		})
+	}
+}
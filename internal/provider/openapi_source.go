@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	"gopkg.in/yaml.v3"
+)
+
+// openAPISourceType is the type of an OpenAPI specification in JSON or YAML.
+// Specifications holding the same document are semantically equal, whatever
+// their format, indentation or key order.
+type openAPISourceType struct {
+	basetypes.StringType
+}
+
+var _ basetypes.StringTypable = openAPISourceType{}
+
+func (t openAPISourceType) Equal(o attr.Type) bool {
+	other, ok := o.(openAPISourceType)
+	return ok && t.StringType.Equal(other.StringType)
+}
+
+func (t openAPISourceType) String() string {
+	return "openAPISourceType"
+}
+
+func (t openAPISourceType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return openAPISourceValue{StringValue: in}, nil
+}
+
+func (t openAPISourceType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	value, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := value.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", value)
+	}
+	return openAPISourceValue{StringValue: stringValue}, nil
+}
+
+func (t openAPISourceType) ValueType(ctx context.Context) attr.Value {
+	return openAPISourceValue{}
+}
+
+// openAPISourceValue is a value of openAPISourceType.
+type openAPISourceValue struct {
+	basetypes.StringValue
+}
+
+var _ basetypes.StringValuableWithSemanticEquals = openAPISourceValue{}
+
+func (v openAPISourceValue) Equal(o attr.Value) bool {
+	other, ok := o.(openAPISourceValue)
+	return ok && v.StringValue.Equal(other.StringValue)
+}
+
+func (v openAPISourceValue) Type(ctx context.Context) attr.Type {
+	return openAPISourceType{}
+}
+
+func (v openAPISourceValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	newValue, diags := newValuable.ToStringValue(ctx)
+	if diags.HasError() {
+		return false, diags
+	}
+	return openAPISourceEqual(v.ValueString(), newValue.ValueString()), nil
+}
+
+// openAPISourcePlanModifier plans spec.source: it is read from
+// spec.source_file when set and its size is validated. The prior source is
+// kept when it is semantically equal to the new one, the framework only
+// checking semantic equality on read and apply, not on plan.
+type openAPISourcePlanModifier struct{}
+
+var _ planmodifier.String = openAPISourcePlanModifier{}
+
+func (m openAPISourcePlanModifier) Description(ctx context.Context) string {
+	return "Reads the source from source_file and keeps the prior source when it holds the same document."
+}
+
+func (m openAPISourcePlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m openAPISourcePlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	sourceFilePath := req.Path.ParentPath().AtName("source_file")
+	var sourceFile types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, sourceFilePath, &sourceFile)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source := req.ConfigValue
+	if !sourceFile.IsNull() {
+		if sourceFile.IsUnknown() {
+			resp.PlanValue = types.StringUnknown()
+			return
+		}
+
+		data, err := os.ReadFile(sourceFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(sourceFilePath, "Invalid OpenAPI File", fmt.Sprintf("Unable to read %s, got error: %s", sourceFile.ValueString(), err))
+			return
+		}
+		source = types.StringValue(string(data))
+	}
+
+	if source.IsNull() || source.IsUnknown() {
+		return
+	}
+
+	spec := &assetsv1.OpenAPISpec{Source: source.ValueString()}
+	if err := spec.Validate(); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid OpenAPI Specification", err.Error())
+		return
+	}
+
+	resp.PlanValue = source
+	if req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		return
+	}
+	equal, diags := openAPISourceValue{StringValue: req.StateValue}.StringSemanticEquals(ctx, openAPISourceValue{StringValue: source})
+	resp.Diagnostics.Append(diags...)
+	if equal {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// openAPISourceEqual returns true if both OpenAPI specifications hold the same
+// document, whatever their format (JSON or YAML), indentation or key order.
+func openAPISourceEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	// JSON being a subset of YAML, both formats are decoded the same way
+	var da, db interface{}
+	if err := yaml.Unmarshal([]byte(a), &da); err != nil {
+		return false
+	}
+	if err := yaml.Unmarshal([]byte(b), &db); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(da, db)
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &OpenAPIsDataSource{}
+
+func NewOpenAPIsDataSource() datasource.DataSource {
+	return &OpenAPIsDataSource{}
+}
+
+// OpenAPIsDataSource defines the data source implementation.
+type OpenAPIsDataSource struct {
+	client assetsv1.Client
+}
+
+// OpenAPIsDataSourceModel describes the data source data model.
+type OpenAPIsDataSourceModel struct {
+	Namespace types.String                    `tfsdk:"namespace"`
+	Items     []assetsv1.OpenAPIResourceModel `tfsdk:"items"`
+}
+
+func (d *OpenAPIsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_openapis"
+}
+
+func (d *OpenAPIsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := listDataSourceAttributes(ctx, NewOpenAPIResource())
+
+	// source_file is only meaningful in a resource configuration
+	items := attributes["items"].(schema.ListNestedAttribute)
+	spec := items.NestedObject.Attributes["spec"].(schema.SingleNestedAttribute)
+	delete(spec.Attributes, "source_file")
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "OpenAPIs data source, lists the OpenAPI specifications of a namespace",
+
+		Attributes: attributes,
+	}
+}
+
+func (d *OpenAPIsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(assetsv1.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *assetsv1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *OpenAPIsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "Reading OpenAPIs")
+
+	// Read Terraform configuration data into the model
+	var state OpenAPIsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	openAPIs, err := d.client.OpenAPI().List(ctx, &metav1.ListOptions{
+		Namespace: state.Namespace.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list openapis, got error: %s", err))
+		return
+	}
+
+	// generate state from protobuf resources
+	state.Items = make([]assetsv1.OpenAPIResourceModel, 0, len(openAPIs.GetItems()))
+	for _, openAPI := range openAPIs.GetItems() {
+		var item assetsv1.OpenAPIResourceModel
+		if _, err := item.FromProto(openAPI); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get state from openapi %s/%s, got error: %s", openAPI.GetMetadata().GetNamespace(), openAPI.GetMetadata().GetName(), err))
+			return
+		}
+		state.Items = append(state.Items, item)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
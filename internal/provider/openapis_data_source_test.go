@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccOpenAPIsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccOpenAPIsDataSourceConfig("tf-acc-test", "tf-acc-tests"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ubika_openapis.test", "namespace", "tf-acc-tests"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.ubika_openapis.test", "items.*", map[string]string{
+						"metadata.name": "tf-acc-test",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccOpenAPIsDataSourceConfig(name string, namespace string) string {
+	return testAccOpenAPIResourceConfig(name, namespace, `{"openapi": "3.0.0", "info": {"title": "tf-acc-test", "version": "1.0.0"}, "paths": {}}`) + fmt.Sprintf(`
+data "ubika_openapis" "test" {
+  namespace = %[1]q
+
+  depends_on = [ubika_openapi.test]
+}
+`, namespace)
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+const (
+	// updateModePatch updates only the changed fields of the objects.
+	updateModePatch = "patch"
+
+	// updateModeReplace replaces the whole objects on update.
+	updateModeReplace = "replace"
+)
+
+// updateModes are the supported values of the update_mode attribute.
+var updateModes = map[string]int32{
+	updateModePatch:   0,
+	updateModeReplace: 1,
+}
+
+// object is an API object with metadata.
+type object interface {
+	proto.Message
+	GetMetadata() *metav1.ObjectMeta
+}
+
+// patcher is implemented by the service clients supporting partial updates of
+// their objects of type T.
+type patcher[T object] interface {
+	Patch(ctx context.Context, in *metav1.PatchOptions, opts ...grpc.CallOption) (T, error)
+}
+
+// updateObject updates item with only the fields changed between the prior
+// state and the plan of req, with the Patch method of the service client svc,
+// so that the fields not managed by Terraform are kept.
+//
+// The whole object is replaced with update when the service does not support
+// partial updates, when they are disabled by the update_mode attribute or when
+// the changes cannot be mapped to fields of the object.
+func updateObject[T object](ctx context.Context, client assetsv1.Client, svc any, req resource.UpdateRequest, item T, update func(context.Context, T, ...grpc.CallOption) (T, error)) (T, error) {
+	p, ok := svc.(patcher[T])
+	if !ok {
+		tflog.Debug(ctx, "Replacing the whole object, the service has no Patch method")
+		return update(ctx, item)
+	}
+	if c, ok := client.(*providerClient); ok && c.updateMode == updateModeReplace {
+		return update(ctx, item)
+	}
+
+	mask, err := changedFieldMask(req.State.Raw, req.Plan.Raw, item)
+	if err != nil {
+		tflog.Debug(ctx, "Replacing the whole object, unable to compute the changed fields", map[string]interface{}{"error": err.Error()})
+		return update(ctx, item)
+	}
+	if len(mask.GetPaths()) == 0 {
+		return update(ctx, item)
+	}
+
+	packed, err := anypb.New(item)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	patched, err := p.Patch(ctx, &metav1.PatchOptions{
+		Namespace: item.GetMetadata().GetNamespace(),
+		Name:      item.GetMetadata().GetName(),
+		Item:      packed,
+		FieldMask: mask,
+	})
+	if status.Code(err) == codes.Unimplemented {
+		tflog.Warn(ctx, "Replacing the whole object, partial updates are not supported", map[string]interface{}{"error": err.Error()})
+		return update(ctx, item)
+	}
+	return patched, err
+}
+
+// changedFieldMask returns the paths of the fields of the message m changed
+// between the prior state and the plan. Lists, sets and maps are replaced as a
+// whole, the computed attributes unknown in the plan are left to the server
+// and the top level attributes which are not fields of m, such as timeouts,
+// only exist on the Terraform side.
+func changedFieldMask(state, plan tftypes.Value, m proto.Message) (*fieldmaskpb.FieldMask, error) {
+	diffs, err := state.Diff(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := m.ProtoReflect().Descriptor().Fields()
+	var paths []string
+	for _, d := range diffs {
+		if isUnknownInPlan(plan, d.Path) {
+			continue
+		}
+		if steps := d.Path.Steps(); len(steps) > 0 {
+			if name, ok := steps[0].(tftypes.AttributeName); ok && fields.ByName(protoreflect.Name(name)) == nil {
+				continue
+			}
+		}
+
+		var names []string
+		for _, step := range d.Path.Steps() {
+			name, ok := step.(tftypes.AttributeName)
+			if !ok {
+				break
+			}
+			names = append(names, string(name))
+		}
+		if len(names) > 0 {
+			paths = append(paths, strings.Join(names, "."))
+		}
+	}
+
+	mask, err := fieldmaskpb.New(m, paths...)
+	if err != nil {
+		return nil, err
+	}
+	mask.Normalize()
+	return mask, nil
+}
+
+// isUnknownInPlan returns true if the value at path p, or one of its parents,
+// is unknown in the plan.
+func isUnknownInPlan(plan tftypes.Value, p *tftypes.AttributePath) bool {
+	steps := p.Steps()
+	for i := 1; i <= len(steps); i++ {
+		v, _, err := tftypes.WalkAttributePath(plan, tftypes.NewAttributePathWithSteps(steps[:i]))
+		if err != nil {
+			return false
+		}
+		if value, ok := v.(tftypes.Value); ok && !value.IsKnown() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakePatchAssetSvcClient is a fakeAssetSvcClient supporting partial updates.
+type fakePatchAssetSvcClient struct {
+	*fakeAssetSvcClient
+	patches       []*metav1.PatchOptions
+	unimplemented bool
+}
+
+func (c *fakePatchAssetSvcClient) Patch(ctx context.Context, in *metav1.PatchOptions, opts ...grpc.CallOption) (*assetsv1.Asset, error) {
+	if c.unimplemented {
+		return nil, status.Error(codes.Unimplemented, "unimplemented")
+	}
+	c.patches = append(c.patches, in)
+
+	var asset assetsv1.Asset
+	if err := in.GetItem().UnmarshalTo(&asset); err != nil {
+		return nil, err
+	}
+	return c.Update(ctx, &asset)
+}
+
+// fakePatchClient is a fakeClient whose asset service supports partial
+// updates.
+type fakePatchClient struct {
+	assetsv1.Client
+	asset *fakePatchAssetSvcClient
+}
+
+func (c *fakePatchClient) Asset() assetsv1.AssetSvcClient {
+	return c.asset
+}
+
+func testPatchAsset() *assetsv1.Asset {
+	asset := assetsv1.NewAsset("tf-acc-test")
+	asset.Metadata.Namespace = "tf-acc-tests"
+	asset.Metadata.Version = 1
+	asset.Spec = &assetsv1.AssetSpec{
+		Hostnames:  []string{"tf-acc-test.example.com"},
+		BackendUrl: "https://backend.example.com",
+	}
+	return asset
+}
+
+func TestChangedFieldMask(t *testing.T) {
+	asset := testPatchAsset()
+	state := testAssetState(t, asset)
+
+	changed := proto.Clone(asset).(*assetsv1.Asset)
+	changed.Spec.BackendUrl = "https://other.example.com"
+	changed.Spec.Hostnames = append(changed.Spec.Hostnames, "other.example.com")
+	changed.Metadata.Version = 2
+	plan := testAssetState(t, changed).Raw
+
+	// the version is computed by the server
+	plan, err := tftypes.Transform(plan, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if p.Equal(tftypes.NewAttributePath().WithAttributeName("metadata").WithAttributeName("version")) {
+			return tftypes.NewValue(v.Type(), tftypes.UnknownValue), nil
+		}
+		return v, nil
+	})
+	require.NoError(t, err)
+
+	mask, err := changedFieldMask(state.Raw, plan, changed)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"spec.backend_url", "spec.hostnames"}, mask.GetPaths())
+
+	// nested attributes which are not fields of the object cannot be patched
+	_, err = changedFieldMask(state.Raw, plan, &assetsv1.Workflow{})
+	assert.Error(t, err)
+
+	// top level attributes which are not fields of the object are skipped
+	mask, err = changedFieldMask(state.Raw, plan, &metav1.UnstructuredObject{})
+	require.NoError(t, err)
+	assert.Empty(t, mask.GetPaths())
+}
+
+func TestAssetResourceUpdatePatch(t *testing.T) {
+	ctx := context.Background()
+
+	asset := testPatchAsset()
+	changed := proto.Clone(asset).(*assetsv1.Asset)
+	changed.Spec.BackendUrl = "https://other.example.com"
+
+	svc := &fakePatchAssetSvcClient{fakeAssetSvcClient: &fakeAssetSvcClient{asset: asset}}
+	client := &providerClient{Client: &fakePatchClient{asset: svc}, updateMode: updateModePatch}
+	r := &AssetResource{client: client}
+
+	update := func() {
+		state := testAssetState(t, svc.asset)
+		plan := testAssetState(t, changed)
+		resp := fwresource.UpdateResponse{State: state}
+		r.Update(ctx, fwresource.UpdateRequest{
+			State: state,
+			Plan:  tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+		}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	}
+
+	// only the changed fields are sent
+	update()
+	require.Len(t, svc.patches, 1)
+	assert.Equal(t, "tf-acc-tests", svc.patches[0].GetNamespace())
+	assert.Equal(t, "tf-acc-test", svc.patches[0].GetName())
+	assert.Equal(t, []string{"spec.backend_url"}, svc.patches[0].GetFieldMask().GetPaths())
+	assert.Equal(t, "https://other.example.com", svc.asset.GetSpec().GetBackendUrl())
+
+	// the whole object is replaced when partial updates are disabled
+	client.updateMode = updateModeReplace
+	changed.Metadata.Version = svc.asset.GetMetadata().GetVersion()
+	changed.Spec.BackendUrl = "https://backend.example.com"
+	update()
+	assert.Len(t, svc.patches, 1)
+	assert.Equal(t, "https://backend.example.com", svc.asset.GetSpec().GetBackendUrl())
+
+	// or not supported
+	client.updateMode = updateModePatch
+	svc.unimplemented = true
+	changed.Metadata.Version = svc.asset.GetMetadata().GetVersion()
+	changed.Spec.BackendUrl = "https://other.example.com"
+	update()
+	assert.Len(t, svc.patches, 1)
+	assert.Equal(t, "https://other.example.com", svc.asset.GetSpec().GetBackendUrl())
+
+	// or when the service has no Patch method
+	client.Client = &fakeClient{asset: svc.fakeAssetSvcClient}
+	changed.Metadata.Version = svc.asset.GetMetadata().GetVersion()
+	changed.Spec.BackendUrl = "https://backend.example.com"
+	update()
+	assert.Len(t, svc.patches, 1)
+	assert.Equal(t, "https://backend.example.com", svc.asset.GetSpec().GetBackendUrl())
+}
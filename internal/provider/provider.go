@@ -3,14 +3,20 @@ package provider
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
 	"github.com/ubikasec/terraform-provider-ubika/internal/auth"
@@ -32,11 +38,40 @@ type UbikaProvider struct {
 
 // UbikaProviderModel describes the provider data model.
 type UbikaProviderModel struct {
-	Host          types.String `tfsdk:"host"`
-	Port          types.String `tfsdk:"port"`
-	InsecureNoTLS types.Bool   `tfsdk:"insecure_no_tls"`
+	Host             types.String `tfsdk:"host"`
+	Port             types.String `tfsdk:"port"`
+	InsecureNoTLS    types.Bool   `tfsdk:"insecure_no_tls"`
+	AuthURL          types.String `tfsdk:"auth_url"`
+	Username         types.String `tfsdk:"username"`
+	Password         types.String `tfsdk:"password"`
+	AccessToken      types.String `tfsdk:"access_token"`
+	ClientID         types.String `tfsdk:"client_id"`
+	ClientSecret     types.String `tfsdk:"client_secret"`
+	Scope            types.String `tfsdk:"scope"`
+	AuthContext      types.String `tfsdk:"auth_context"`
+	AuthCachePath    types.String `tfsdk:"auth_cache_path"`
+	CACert           types.String `tfsdk:"ca_cert"`
+	CACertFile       types.String `tfsdk:"ca_cert_file"`
+	ClientCert       types.String `tfsdk:"client_cert"`
+	ClientKey        types.String `tfsdk:"client_key"`
+	TLSServerName    types.String `tfsdk:"tls_server_name"`
+	TLSMinVersion    types.String `tfsdk:"tls_min_version"`
+	MaxRetries       types.Int64  `tfsdk:"max_retries"`
+	RetryMaxWait     types.String `tfsdk:"retry_max_wait"`
+	SkipVersionCheck types.Bool   `tfsdk:"skip_version_check"`
+	UpdateMode       types.String `tfsdk:"update_mode"`
 }
 
+// tlsVersions are the supported values of the tls_min_version attribute.
+var tlsVersions = map[string]int32{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// defaultAuthURL is the authentication server used when only credentials are
+// configured.
+const defaultAuthURL = "login.ubika.io"
+
 func (p *UbikaProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "ubika"
 	resp.Version = p.version
@@ -57,6 +92,98 @@ func (p *UbikaProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				MarkdownDescription: "disable TLS ",
 				Optional:            true,
 			},
+			"auth_url": schema.StringAttribute{
+				MarkdownDescription: "Authentication server URL, defaults to `" + defaultAuthURL + "`. Can also be set with the `UBIKA_AUTH_URL` environment variable.",
+				Optional:            true,
+			},
+			"username": schema.StringAttribute{
+				MarkdownDescription: "Username to authenticate with. Can also be set with the `UBIKA_USERNAME` environment variable.",
+				Optional:            true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password of the user. Can also be set with the `UBIKA_PASSWORD` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "Pre-issued access token, conflicts with `username`. Can also be set with the `UBIKA_ACCESS_TOKEN` environment variable. " +
+					"When no credentials are configured, the token of the current appsecctl context is used.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"client_id": schema.StringAttribute{
+				MarkdownDescription: "Client ID of a service account, authenticated with the OAuth2 client credentials grant. Conflicts with `username` and `access_token`. " +
+					"Can also be set with the `UBIKA_CLIENT_ID` environment variable.",
+				Optional: true,
+			},
+			"client_secret": schema.StringAttribute{
+				MarkdownDescription: "Client secret of the service account. Can also be set with the `UBIKA_CLIENT_SECRET` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Space separated scopes requested for the service account. Can also be set with the `UBIKA_SCOPE` environment variable.",
+				Optional:            true,
+			},
+			"auth_context": schema.StringAttribute{
+				MarkdownDescription: "appsecctl context to authenticate with, defaults to the current context. Ignored when credentials are configured. " +
+					"Can also be set with the `UBIKA_AUTH_CONTEXT` environment variable.",
+				Optional: true,
+			},
+			"auth_cache_path": schema.StringAttribute{
+				MarkdownDescription: "Path of the appsecctl authentication cache file, defaults to `.appsecctl` in the user cache directory. Ignored when credentials are configured. " +
+					"Can also be set with the `UBIKA_AUTH_CACHE_PATH` or `APPSECCTL_CACHE_PATH` environment variables.",
+				Optional: true,
+			},
+			"ca_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificates used to verify the API and authentication servers, instead of the system ones. Conflicts with `ca_cert_file`. " +
+					"Can also be set with the `UBIKA_CA_CERT` environment variable.",
+				Optional: true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a PEM encoded CA certificates file used to verify the API and authentication servers, instead of the system ones. " +
+					"Can also be set with the `UBIKA_CA_CERT_FILE` environment variable.",
+				Optional: true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate for mutual TLS. Can also be set with the `UBIKA_CLIENT_CERT` environment variable.",
+				Optional:            true,
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key of the client certificate. Can also be set with the `UBIKA_CLIENT_KEY` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"tls_server_name": schema.StringAttribute{
+				MarkdownDescription: "Server name used to verify the API server certificate, defaults to `host`. Can also be set with the `UBIKA_TLS_SERVER_NAME` environment variable.",
+				Optional:            true,
+			},
+			"tls_min_version": schema.StringAttribute{
+				MarkdownDescription: "Minimum TLS version, defaults to `1.2`. Can also be set with the `UBIKA_TLS_MIN_VERSION` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					newEnumValidator(tlsVersions),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of retries of an API call failing with a transient error, defaults to `%d`. `0` disables retries. Creations whose retry fails because the resource already exists read it back when it has the requested spec, updates of a known version are not retried when the API is unavailable. Streams watching the assets are not retried.", defaultMaxRetries),
+				Optional:            true,
+			},
+			"retry_max_wait": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Maximum wait between two retries, as a duration such as `10s`, defaults to `%s`.", defaultRetryMaxWait),
+				Optional:            true,
+			},
+			"skip_version_check": schema.BoolAttribute{
+				MarkdownDescription: "Update resources even if they were changed since they were last read, overwriting the changes. By default, such updates fail and the state must be refreshed first.",
+				Optional:            true,
+			},
+			"update_mode": schema.StringAttribute{
+				MarkdownDescription: "How resources are updated, defaults to `patch`: only the changed attributes are sent, keeping the fields not managed by Terraform, the whole resource is replaced as a fallback by the services without a patch method, as are all the services of the current API. `replace` always replaces the whole resource.",
+				Optional:            true,
+				Validators: []validator.String{
+					newEnumValidator(updateModes),
+				},
+			},
 		},
 	}
 }
@@ -84,8 +211,72 @@ func (p *UbikaProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		data.InsecureNoTLS = types.BoolValue(false)
 	}
 
-	token, _, err := auth.GetToken(http.DefaultClient, ".appsecctl")
+	tlsConfig, err := newTLSConfig(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Provider Error", fmt.Sprintf("Invalid TLS configuration: %s", err))
+		return
+	}
+
+	// the authentication server is not the API server, its name is kept
+	httpTLSConfig := tlsConfig.Clone()
+	httpTLSConfig.ServerName = ""
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = httpTLSConfig
+	httpClient := &http.Client{Transport: transport}
+
+	maxRetries := int64(defaultMaxRetries)
+	if !data.MaxRetries.IsNull() {
+		maxRetries = data.MaxRetries.ValueInt64()
+	}
+	if maxRetries < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_retries"), "Invalid Attribute Value", fmt.Sprintf("max_retries must not be negative, got: %d", maxRetries))
+		return
+	}
+
+	retryMaxWait := defaultRetryMaxWait
+	if !data.RetryMaxWait.IsNull() && data.RetryMaxWait.ValueString() != "" {
+		retryMaxWait, err = time.ParseDuration(data.RetryMaxWait.ValueString())
+		if err != nil || retryMaxWait <= 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("retry_max_wait"), "Invalid Attribute Value", fmt.Sprintf("retry_max_wait must be a positive duration, got: %q", data.RetryMaxWait.ValueString()))
+			return
+		}
+	}
+
+	authentifier, err := newAuthentifier(data, httpClient)
 	if err != nil {
+		resp.Diagnostics.AddError("Provider Error", fmt.Sprintf("Invalid authentication configuration: %s", err))
+		return
+	}
+
+	// the token source renews the access token when needed during the whole
+	// provider lifetime
+	var tokenSource auth.TokenSource
+	if authentifier == nil {
+		var config auth.Config
+		if cachePath := stringValueOrEnv(data.AuthCachePath, "UBIKA_AUTH_CACHE_PATH"); cachePath != "" {
+			config, err = auth.LoadPath(cachePath)
+		} else {
+			config, err = auth.Load(".appsecctl")
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Provider Error", fmt.Sprintf("Unable to load authentication configuration, got error: %s", err))
+			return
+		}
+
+		tokenSource, err = auth.NewConfigTokenSource(httpClient, config, stringValueOrEnv(data.AuthContext, "UBIKA_AUTH_CONTEXT"))
+		if err != nil {
+			resp.Diagnostics.AddError("Provider Error", fmt.Sprintf("Unable to find authentication token, got error: %s", err))
+			return
+		}
+	} else {
+		if err := authentifier.Login(); err != nil {
+			resp.Diagnostics.AddError("Provider Error", fmt.Sprintf("Unable to authenticate, got error: %s", err))
+			return
+		}
+		tokenSource = auth.NewTokenSource(authentifier, nil)
+	}
+
+	if _, err := tokenSource.Token(); err != nil {
 		resp.Diagnostics.AddError("Provider Error", fmt.Sprintf("Unable to find authentication token, got error: %s", err))
 		return
 	}
@@ -95,33 +286,169 @@ func (p *UbikaProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		transportCredentials = insecure.NewCredentials()
 
 	} else {
-		transportCredentials = credentials.NewTLS(&tls.Config{})
+		transportCredentials = credentials.NewTLS(tlsConfig)
 	}
 
 	conn, err := grpc.Dial(
 		fmt.Sprintf("dns:///%s", endpoint),
 		grpc.WithTransportCredentials(transportCredentials),
-		grpc.WithPerRPCCredentials(auth.NewPerRPCCredentials("bearer", token)),
+		grpc.WithPerRPCCredentials(auth.NewPerRPCCredentialsFromSource("bearer", tokenSource)),
+		grpc.WithUnaryInterceptor(newRetryInterceptor(int(maxRetries), retryMaxWait)),
 	)
 	if err != nil {
 		resp.Diagnostics.AddError("Provider Error", fmt.Sprintf("Unable to connect, got error: %s", err))
 		return
 	}
 
-	client := assetsv1.NewGRPCClient(conn)
+	client := &providerClient{
+		Client:           assetsv1.NewGRPCClient(conn),
+		skipVersionCheck: data.SkipVersionCheck.ValueBool(),
+		updateMode:       updateModePatch,
+	}
+	if !data.UpdateMode.IsNull() && data.UpdateMode.ValueString() != "" {
+		client.updateMode = data.UpdateMode.ValueString()
+	}
 	resp.DataSourceData = client
 	resp.ResourceData = client
 }
 
+// newAuthentifier returns the authentication configuration described by the
+// provider attributes, or their UBIKA_* environment variables. It returns nil
+// when no credentials are configured, the appsecctl cache file is then used.
+// Nothing is written to the cache file.
+func newAuthentifier(data UbikaProviderModel, httpClient *http.Client) (auth.Authentifier, error) {
+	authURL := stringValueOrEnv(data.AuthURL, "UBIKA_AUTH_URL")
+	username := stringValueOrEnv(data.Username, "UBIKA_USERNAME")
+	password := stringValueOrEnv(data.Password, "UBIKA_PASSWORD")
+	accessToken := stringValueOrEnv(data.AccessToken, "UBIKA_ACCESS_TOKEN")
+	clientID := stringValueOrEnv(data.ClientID, "UBIKA_CLIENT_ID")
+	clientSecret := stringValueOrEnv(data.ClientSecret, "UBIKA_CLIENT_SECRET")
+	scope := stringValueOrEnv(data.Scope, "UBIKA_SCOPE")
+
+	if authURL == "" {
+		authURL = defaultAuthURL
+	}
+
+	switch {
+	case accessToken != "" && username != "":
+		return nil, errors.New("access_token conflicts with username")
+	case clientID != "" && username != "":
+		return nil, errors.New("client_id conflicts with username")
+	case clientID != "" && accessToken != "":
+		return nil, errors.New("client_id conflicts with access_token")
+	case accessToken != "":
+		return auth.NewTokenAuthConfig(accessToken), nil
+	case clientID != "":
+		if clientSecret == "" {
+			return nil, errors.New("client_secret is required with client_id")
+		}
+		return auth.NewClientCredentialsAuthConfig(httpClient, clientID, clientSecret, scope, authURL), nil
+	case clientSecret != "":
+		return nil, errors.New("client_id is required with client_secret")
+	case username != "":
+		if password == "" {
+			return nil, errors.New("password is required with username")
+		}
+		return auth.NewKeycloakAuthConfig(httpClient, username, password, authURL), nil
+	case password != "":
+		return nil, errors.New("username is required with password")
+	}
+
+	return nil, nil
+}
+
+// newTLSConfig returns the TLS configuration described by the provider
+// attributes, or their UBIKA_* environment variables.
+func newTLSConfig(data UbikaProviderModel) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: stringValueOrEnv(data.TLSServerName, "UBIKA_TLS_SERVER_NAME"),
+	}
+
+	if minVersion := stringValueOrEnv(data.TLSMinVersion, "UBIKA_TLS_MIN_VERSION"); minVersion != "" {
+		version, ok := tlsVersions[minVersion]
+		if !ok {
+			return nil, fmt.Errorf("unsupported tls_min_version %q", minVersion)
+		}
+		tlsConfig.MinVersion = uint16(version)
+	}
+
+	caCert := stringValueOrEnv(data.CACert, "UBIKA_CA_CERT")
+	caCertFile := stringValueOrEnv(data.CACertFile, "UBIKA_CA_CERT_FILE")
+	if caCert != "" && caCertFile != "" {
+		return nil, errors.New("ca_cert conflicts with ca_cert_file")
+	}
+	if caCertFile != "" {
+		b, err := os.ReadFile(caCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read ca_cert_file: %s", err)
+		}
+		caCert = string(b)
+	}
+	if caCert != "" {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM([]byte(caCert)) {
+			return nil, errors.New("no PEM encoded certificate found in the CA certificates")
+		}
+	}
+
+	clientCert := stringValueOrEnv(data.ClientCert, "UBIKA_CLIENT_CERT")
+	clientKey := stringValueOrEnv(data.ClientKey, "UBIKA_CLIENT_KEY")
+	if (clientCert == "") != (clientKey == "") {
+		return nil, errors.New("client_cert and client_key must be set together")
+	}
+	if clientCert != "" {
+		cert, err := tls.X509KeyPair([]byte(clientCert), []byte(clientKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// stringValueOrEnv returns the value of a provider attribute, or of the
+// environment variable if the attribute is not set.
+func stringValueOrEnv(v types.String, env string) string {
+	if v.IsNull() || v.IsUnknown() || v.ValueString() == "" {
+		return os.Getenv(env)
+	}
+	return v.ValueString()
+}
+
 func (p *UbikaProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewAssetResource,
 		NewErrorDocumentResource,
+		NewWorkflowResource,
+		NewOpenAPIResource,
+		NewExceptionProfileResource,
+		NewTLSConfigurationResource,
+		NewTLSMaterialResource,
+		NewTLSCSRResource,
+		NewTLSCSRCertificateResource,
+		NewIPBlacklistResource,
 	}
 }
 
 func (p *UbikaProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewAssetDataSource,
+		NewAssetsDataSource,
+		NewTLSConfigurationDefaultsDataSource,
+		NewTLSMaterialsDataSource,
+		NewErrorDocumentDataSource,
+		NewErrorDocumentsDataSource,
+		NewWorkflowDataSource,
+		NewWorkflowsDataSource,
+		NewOpenAPIDataSource,
+		NewOpenAPIsDataSource,
+		NewExceptionProfileDataSource,
+		NewExceptionProfilesDataSource,
+		NewIPBlacklistDataSource,
+		NewIPBlacklistsDataSource,
+	}
 }
 
 func New(version string) func() provider.Provider {
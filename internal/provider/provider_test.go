@@ -1,10 +1,18 @@
 package provider
 
 import (
+	"crypto/tls"
+	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubikasec/terraform-provider-ubika/internal/auth"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -20,3 +28,169 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+func TestNewAuthentifier(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     UbikaProviderModel
+		env      map[string]string
+		wantType interface{}
+		wantErr  bool
+	}{
+		{"no credentials", UbikaProviderModel{}, nil, nil, false},
+		{
+			"access token",
+			UbikaProviderModel{AccessToken: types.StringValue("token")},
+			nil, &auth.TokenAuthConfig{}, false,
+		},
+		{
+			"access token from environment",
+			UbikaProviderModel{},
+			map[string]string{"UBIKA_ACCESS_TOKEN": "token"},
+			&auth.TokenAuthConfig{}, false,
+		},
+		{
+			"username and password",
+			UbikaProviderModel{Username: types.StringValue("user"), Password: types.StringValue("pwd")},
+			nil, &auth.KeycloakAuthConfig{}, false,
+		},
+		{
+			"password from environment",
+			UbikaProviderModel{Username: types.StringValue("user")},
+			map[string]string{"UBIKA_PASSWORD": "pwd"},
+			&auth.KeycloakAuthConfig{}, false,
+		},
+		{
+			"missing password",
+			UbikaProviderModel{Username: types.StringValue("user")},
+			nil, nil, true,
+		},
+		{
+			"missing username",
+			UbikaProviderModel{Password: types.StringValue("pwd")},
+			nil, nil, true,
+		},
+		{
+			"client credentials",
+			UbikaProviderModel{ClientID: types.StringValue("client"), ClientSecret: types.StringValue("secret")},
+			nil, &auth.ClientCredentialsAuthConfig{}, false,
+		},
+		{
+			"client credentials from environment",
+			UbikaProviderModel{},
+			map[string]string{"UBIKA_CLIENT_ID": "client", "UBIKA_CLIENT_SECRET": "secret"},
+			&auth.ClientCredentialsAuthConfig{}, false,
+		},
+		{
+			"missing client secret",
+			UbikaProviderModel{ClientID: types.StringValue("client")},
+			nil, nil, true,
+		},
+		{
+			"missing client id",
+			UbikaProviderModel{ClientSecret: types.StringValue("secret")},
+			nil, nil, true,
+		},
+		{
+			"client id conflicts with username",
+			UbikaProviderModel{Username: types.StringValue("user"), Password: types.StringValue("pwd"), ClientID: types.StringValue("client"), ClientSecret: types.StringValue("secret")},
+			nil, nil, true,
+		},
+		{
+			"access token conflicts with username",
+			UbikaProviderModel{Username: types.StringValue("user"), Password: types.StringValue("pwd"), AccessToken: types.StringValue("token")},
+			nil, nil, true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, env := range []string{"UBIKA_AUTH_URL", "UBIKA_USERNAME", "UBIKA_PASSWORD", "UBIKA_ACCESS_TOKEN", "UBIKA_CLIENT_ID", "UBIKA_CLIENT_SECRET", "UBIKA_SCOPE"} {
+				t.Setenv(env, tc.env[env])
+			}
+
+			a, err := newAuthentifier(tc.data, http.DefaultClient)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tc.wantType == nil {
+				assert.Nil(t, a)
+			} else {
+				assert.IsType(t, tc.wantType, a)
+			}
+		})
+	}
+}
+
+func TestNewAuthentifierAuthURL(t *testing.T) {
+	t.Setenv("UBIKA_AUTH_URL", "https://auth.example.com")
+
+	a, err := newAuthentifier(UbikaProviderModel{Username: types.StringValue("user"), Password: types.StringValue("pwd")}, http.DefaultClient)
+	require.NoError(t, err)
+	assert.Equal(t, "https://auth.example.com/auth/realms/main", a.(*auth.KeycloakAuthConfig).BaseURL)
+
+	a, err = newAuthentifier(UbikaProviderModel{AuthURL: types.StringValue("https://other.example.com"), Username: types.StringValue("user"), Password: types.StringValue("pwd")}, http.DefaultClient)
+	require.NoError(t, err)
+	assert.Equal(t, "https://other.example.com/auth/realms/main", a.(*auth.KeycloakAuthConfig).BaseURL)
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	caCert, _ := testAccTLSMaterialCertificate(t, "ca.example.com")
+	clientCert, clientKey := testAccTLSMaterialCertificate(t, "client.example.com")
+
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caCertFile, []byte(caCert), 0o600))
+
+	testCases := []struct {
+		name           string
+		data           UbikaProviderModel
+		wantCA         bool
+		wantClientCert bool
+		wantMinVersion uint16
+		wantErr        bool
+	}{
+		{"default", UbikaProviderModel{}, false, false, tls.VersionTLS12, false},
+		{"inline CA", UbikaProviderModel{CACert: types.StringValue(caCert)}, true, false, tls.VersionTLS12, false},
+		{"CA file", UbikaProviderModel{CACertFile: types.StringValue(caCertFile)}, true, false, tls.VersionTLS12, false},
+		{"missing CA file", UbikaProviderModel{CACertFile: types.StringValue(filepath.Join(t.TempDir(), "missing.pem"))}, false, false, 0, true},
+		{"invalid CA", UbikaProviderModel{CACert: types.StringValue("invalid")}, false, false, 0, true},
+		{"CA conflict", UbikaProviderModel{CACert: types.StringValue(caCert), CACertFile: types.StringValue(caCertFile)}, false, false, 0, true},
+		{"client certificate", UbikaProviderModel{ClientCert: types.StringValue(clientCert), ClientKey: types.StringValue(clientKey)}, false, true, tls.VersionTLS12, false},
+		{"missing client key", UbikaProviderModel{ClientCert: types.StringValue(clientCert)}, false, false, 0, true},
+		{"mismatched client key", UbikaProviderModel{ClientCert: types.StringValue(clientCert), ClientKey: types.StringValue(caCert)}, false, false, 0, true},
+		{"TLS 1.3", UbikaProviderModel{TLSMinVersion: types.StringValue("1.3")}, false, false, tls.VersionTLS13, false},
+		{"unsupported TLS version", UbikaProviderModel{TLSMinVersion: types.StringValue("1.0")}, false, false, 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, env := range []string{"UBIKA_CA_CERT", "UBIKA_CA_CERT_FILE", "UBIKA_CLIENT_CERT", "UBIKA_CLIENT_KEY", "UBIKA_TLS_SERVER_NAME", "UBIKA_TLS_MIN_VERSION"} {
+				t.Setenv(env, "")
+			}
+
+			tlsConfig, err := newTLSConfig(tc.data)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCA, tlsConfig.RootCAs != nil)
+			assert.Equal(t, tc.wantClientCert, len(tlsConfig.Certificates) == 1)
+			assert.Equal(t, tc.wantMinVersion, tlsConfig.MinVersion)
+		})
+	}
+}
+
+func TestNewTLSConfigServerName(t *testing.T) {
+	t.Setenv("UBIKA_TLS_SERVER_NAME", "api.example.com")
+
+	tlsConfig, err := newTLSConfig(UbikaProviderModel{})
+	require.NoError(t, err)
+	assert.Equal(t, "api.example.com", tlsConfig.ServerName)
+
+	tlsConfig, err = newTLSConfig(UbikaProviderModel{TLSServerName: types.StringValue("other.example.com")})
+	require.NoError(t, err)
+	assert.Equal(t, "other.example.com", tlsConfig.ServerName)
+}
@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// defaultMaxRetries is the default number of retries of a failed call.
+	defaultMaxRetries = 3
+
+	// defaultRetryMaxWait is the default maximum wait between two retries.
+	defaultRetryMaxWait = 30 * time.Second
+
+	// retryBaseWait is the wait before the first retry, doubled on each retry.
+	retryBaseWait = 500 * time.Millisecond
+)
+
+// retryableCodes are the status codes of transient errors. The call was
+// usually not processed, but an Unavailable error may also be returned when
+// the connection is lost after the request was sent.
+var retryableCodes = map[codes.Code]bool{
+	codes.Unavailable:       true,
+	codes.ResourceExhausted: true,
+}
+
+// newRetryInterceptor returns a gRPC interceptor retrying unary calls failing
+// with a transient error, up to maxRetries times. Retries are delayed with an
+// exponential backoff and jitter, or as requested by the server with a
+// retry-after metadata or a RetryInfo error detail, at most maxWait.
+//
+// A failed Create may have created the object, a retry then fails with
+// AlreadyExists and the object is read back instead, as long as its spec is
+// the requested one. A retried Delete may likewise fail with NotFound, which
+// the resources already ignore. An Update or Patch sending the prior version
+// is not retried on Unavailable: if the first attempt was applied, the retry
+// would be rejected as a conflict.
+//
+// Only unary calls are retried, streams such as the Watch of the assets are
+// not: watchAsset polls the asset when its stream fails.
+func newRetryInterceptor(maxRetries int, maxWait time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		for attempt := 0; ; attempt++ {
+			var header, trailer metadata.MD
+			err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header), grpc.Trailer(&trailer))...)
+			if attempt > 0 && status.Code(err) == codes.AlreadyExists && strings.HasSuffix(method, "/Create") {
+				return readBackCreated(ctx, method, req, reply, cc, invoker, err, opts...)
+			}
+			if err == nil || attempt >= maxRetries || !isRetryable(method, req, err) {
+				return err
+			}
+
+			wait, ok := serverRetryDelay(err, header, trailer)
+			if !ok {
+				wait = backoff(attempt, maxWait)
+			}
+			if wait > maxWait {
+				wait = maxWait
+			}
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// isRetryable returns true if the call of method with req failing with err
+// can be retried.
+func isRetryable(method string, req interface{}, err error) bool {
+	code := status.Code(err)
+	if !retryableCodes[code] {
+		return false
+	}
+	if code == codes.Unavailable && (strings.HasSuffix(method, "/Update") || strings.HasSuffix(method, "/Patch")) {
+		return sentVersion(req) == 0
+	}
+	return true
+}
+
+// sentVersion returns the version of the object updated or patched by req.
+func sentVersion(req interface{}) int64 {
+	if patch, ok := req.(*metav1.PatchOptions); ok {
+		item, err := patch.GetItem().UnmarshalNew()
+		if err != nil {
+			return 0
+		}
+		req = item
+	}
+	if obj, ok := req.(object); ok {
+		return obj.GetMetadata().GetVersion()
+	}
+	return 0
+}
+
+// readBackCreated reads back into reply the object req of the retried Create
+// method which failed with the AlreadyExists error err, the object being
+// likely created by a prior attempt. err is returned when the object cannot
+// be read or was not created with the spec of req, it then rather belongs to
+// another client.
+func readBackCreated(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, err error, opts ...grpc.CallOption) error {
+	obj, ok := req.(object)
+	if !ok {
+		return err
+	}
+	out, ok := reply.(proto.Message)
+	if !ok {
+		return err
+	}
+
+	tflog.Warn(ctx, "Object already exists after a retried creation, reading it back", map[string]interface{}{"method": method})
+	got := out.ProtoReflect().New().Interface()
+	if getErr := invoker(ctx, strings.TrimSuffix(method, "Create")+"Get", &metav1.GetOptions{
+		Namespace: obj.GetMetadata().GetNamespace(),
+		Name:      obj.GetMetadata().GetName(),
+	}, got, cc, opts...); getErr != nil {
+		return err
+	}
+
+	if !proto.Equal(specOf(obj), specOf(got)) {
+		return status.Errorf(codes.AlreadyExists, "%s: it was not created by this provider, import it with terraform import to manage it", status.Convert(err).Message())
+	}
+	proto.Reset(out)
+	proto.Merge(out, got)
+	return nil
+}
+
+// specOf returns the spec field of m, or nil if it has none.
+func specOf(m proto.Message) proto.Message {
+	r := m.ProtoReflect()
+	field := r.Descriptor().Fields().ByName("spec")
+	if field == nil || field.Message() == nil || !r.Has(field) {
+		return nil
+	}
+	return r.Get(field).Message().Interface()
+}
+
+// backoff returns the wait before a retry: an exponential backoff with
+// jitter, at most maxWait.
+func backoff(attempt int, maxWait time.Duration) time.Duration {
+	wait := maxWait
+	if attempt < 32 && retryBaseWait<<attempt < maxWait {
+		wait = retryBaseWait << attempt
+	}
+	// full jitter on the second half to keep some backoff
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// serverRetryDelay returns the wait before a retry requested by the server.
+func serverRetryDelay(err error, mds ...metadata.MD) (time.Duration, bool) {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			return info.GetRetryDelay().AsDuration(), true
+		}
+	}
+
+	for _, md := range mds {
+		for _, value := range md.Get("retry-after") {
+			if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+				return time.Duration(seconds) * time.Second, true
+			}
+			if date, err := http.ParseTime(value); err == nil {
+				wait := time.Until(date)
+				if wait < 0 {
+					wait = 0
+				}
+				return wait, true
+			}
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// fakeInvoker fails with errs, one per call, then succeeds with got as reply
+// when set. trailer is set on each failed call.
+type fakeInvoker struct {
+	errs    []error
+	trailer metadata.MD
+	got     proto.Message
+	calls   int
+	methods []string
+}
+
+func (f *fakeInvoker) invoke(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+	f.calls++
+	f.methods = append(f.methods, method)
+	if f.calls > len(f.errs) {
+		if out, ok := reply.(proto.Message); ok && f.got != nil {
+			proto.Merge(out, f.got)
+		}
+		return nil
+	}
+	for _, opt := range opts {
+		if trailer, ok := opt.(grpc.TrailerCallOption); ok && f.trailer != nil {
+			*trailer.TrailerAddr = f.trailer
+		}
+	}
+	return f.errs[f.calls-1]
+}
+
+func TestRetryInterceptor(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	exhausted := status.Error(codes.ResourceExhausted, "exhausted")
+	notFound := status.Error(codes.NotFound, "not found")
+
+	testCases := []struct {
+		name       string
+		errs       []error
+		maxRetries int
+		wantCalls  int
+		wantErr    error
+	}{
+		{"success", nil, 3, 1, nil},
+		{"transient errors", []error{unavailable, exhausted}, 3, 3, nil},
+		{"too many transient errors", []error{unavailable, unavailable, unavailable}, 2, 3, unavailable},
+		{"retries disabled", []error{unavailable}, 0, 1, unavailable},
+		{"not retryable", []error{notFound}, 3, 1, notFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			invoker := &fakeInvoker{errs: tc.errs}
+			interceptor := newRetryInterceptor(tc.maxRetries, time.Millisecond)
+
+			err := interceptor(context.Background(), "/test", nil, nil, nil, invoker.invoke)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCalls, invoker.calls)
+		})
+	}
+}
+
+func TestRetryInterceptorContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	invoker := &fakeInvoker{errs: []error{status.Error(codes.Unavailable, "unavailable")}}
+	interceptor := newRetryInterceptor(3, time.Hour)
+
+	err := interceptor(ctx, "/test", nil, nil, nil, invoker.invoke)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, invoker.calls)
+}
+
+func TestRetryInterceptorCreate(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	alreadyExists := status.Error(codes.AlreadyExists, "already exists")
+	asset := assetsv1.NewAsset("test")
+	asset.Metadata.Namespace = "ns"
+	asset.Spec.BackendUrl = "https://backend.example.com"
+	other := proto.Clone(asset).(*assetsv1.Asset)
+	other.Spec.BackendUrl = "https://other.example.com"
+
+	create, get := assetsv1.AssetSvc_Create_FullMethodName, assetsv1.AssetSvc_Get_FullMethodName
+	testCases := []struct {
+		name        string
+		errs        []error
+		got         *assetsv1.Asset
+		wantMethods []string
+		wantCode    codes.Code
+	}{
+		{"created", []error{unavailable}, asset, []string{create, create}, codes.OK},
+		{"created before retry", []error{unavailable, alreadyExists}, asset, []string{create, create, get}, codes.OK},
+		{"created by another client", []error{unavailable, alreadyExists}, other, []string{create, create, get}, codes.AlreadyExists},
+		{"already exists", []error{alreadyExists}, asset, []string{create}, codes.AlreadyExists},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			invoker := &fakeInvoker{errs: tc.errs, got: tc.got}
+			interceptor := newRetryInterceptor(3, time.Millisecond)
+
+			reply := &assetsv1.Asset{}
+			err := interceptor(context.Background(), create, asset, reply, nil, invoker.invoke)
+			assert.Equal(t, tc.wantCode, status.Code(err))
+			assert.Equal(t, tc.wantMethods, invoker.methods)
+			if tc.wantCode == codes.OK {
+				assert.True(t, proto.Equal(asset, reply))
+			}
+			if tc.name == "created by another client" {
+				assert.Contains(t, err.Error(), "terraform import")
+			}
+		})
+	}
+}
+
+func TestRetryInterceptorUpdate(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	asset := assetsv1.NewAsset("test")
+	asset.Metadata.Namespace = "ns"
+
+	// the update may have been applied, a retry would be rejected as a conflict
+	asset.Metadata.Version = 2
+	invoker := &fakeInvoker{errs: []error{unavailable}}
+	err := newRetryInterceptor(3, time.Millisecond)(context.Background(), assetsv1.AssetSvc_Update_FullMethodName, asset, &assetsv1.Asset{}, nil, invoker.invoke)
+	assert.Equal(t, unavailable, err)
+	assert.Equal(t, 1, invoker.calls)
+
+	// an update without version is retried
+	asset.Metadata.Version = 0
+	invoker = &fakeInvoker{errs: []error{unavailable}}
+	err = newRetryInterceptor(3, time.Millisecond)(context.Background(), assetsv1.AssetSvc_Update_FullMethodName, asset, &assetsv1.Asset{}, nil, invoker.invoke)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, invoker.calls)
+
+	// nor is a patch of a version
+	asset.Metadata.Version = 2
+	item, err := anypb.New(asset)
+	require.NoError(t, err)
+	invoker = &fakeInvoker{errs: []error{unavailable}}
+	err = newRetryInterceptor(3, time.Millisecond)(context.Background(), "/assets.ubika.io.v1beta.AssetSvc/Patch", &metav1.PatchOptions{Item: item}, &assetsv1.Asset{}, nil, invoker.invoke)
+	assert.Equal(t, unavailable, err)
+	assert.Equal(t, 1, invoker.calls)
+}
+
+func TestRetryInterceptorRetryAfter(t *testing.T) {
+	invoker := &fakeInvoker{
+		errs:    []error{status.Error(codes.Unavailable, "unavailable")},
+		trailer: metadata.Pairs("retry-after", "1"),
+	}
+	interceptor := newRetryInterceptor(3, 50*time.Millisecond)
+
+	start := time.Now()
+	err := interceptor(context.Background(), "/test", nil, nil, nil, invoker.invoke)
+	require.NoError(t, err)
+	assert.Equal(t, 2, invoker.calls)
+
+	// the requested second is capped by the maximum wait
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed, 50*time.Millisecond)
+	assert.Less(t, elapsed, time.Second)
+}
+
+func TestServerRetryDelay(t *testing.T) {
+	withRetryInfo, err := status.New(codes.ResourceExhausted, "exhausted").WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(2 * time.Second)})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		err      error
+		md       metadata.MD
+		wantWait time.Duration
+		wantOk   bool
+	}{
+		{"none", status.Error(codes.Unavailable, "unavailable"), nil, 0, false},
+		{"retry info", withRetryInfo.Err(), nil, 2 * time.Second, true},
+		{"retry-after seconds", status.Error(codes.Unavailable, "unavailable"), metadata.Pairs("retry-after", "3"), 3 * time.Second, true},
+		{"retry-after past date", status.Error(codes.Unavailable, "unavailable"), metadata.Pairs("retry-after", "Wed, 21 Oct 2015 07:28:00 GMT"), 0, true},
+		{"invalid retry-after", status.Error(codes.Unavailable, "unavailable"), metadata.Pairs("retry-after", "soon"), 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			wait, ok := serverRetryDelay(tc.err, tc.md)
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.wantWait, wait)
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := 0; attempt < 64; attempt++ {
+		wait := backoff(attempt, 10*time.Second)
+
+		want := 10 * time.Second
+		if attempt < 5 {
+			want = retryBaseWait << attempt
+		}
+		assert.GreaterOrEqual(t, wait, want/2)
+		assert.LessOrEqual(t, wait, want)
+	}
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TLSConfigurationDefaultsDataSource{}
+
+func NewTLSConfigurationDefaultsDataSource() datasource.DataSource {
+	return &TLSConfigurationDefaultsDataSource{}
+}
+
+// TLSConfigurationDefaultsDataSource defines the data source implementation.
+type TLSConfigurationDefaultsDataSource struct {
+	client assetsv1.Client
+}
+
+// TLSConfigurationDefaultsDataSourceModel describes the data source data model.
+type TLSConfigurationDefaultsDataSourceModel struct {
+	SelfHosted         types.Bool   `tfsdk:"self_hosted"`
+	CiphersAvailable   []string     `tfsdk:"ciphers_available"`
+	CiphersDefault     []string     `tfsdk:"ciphers_default"`
+	ProtocolMinDefault types.String `tfsdk:"protocol_min_default"`
+	ProtocolMaxDefault types.String `tfsdk:"protocol_max_default"`
+}
+
+func (d *TLSConfigurationDefaultsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tls_configuration_defaults"
+}
+
+func (d *TLSConfigurationDefaultsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "TLS configuration defaults data source, the TLS protocols and ciphers supported by the platform",
+
+		Attributes: map[string]schema.Attribute{
+			"self_hosted": schema.BoolAttribute{
+				MarkdownDescription: "Return the defaults of self hosted deployments instead of SaaS ones",
+				Optional:            true,
+			},
+			"ciphers_available": schema.SetAttribute{
+				MarkdownDescription: "Ciphers available on the platform",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"ciphers_default": schema.SetAttribute{
+				MarkdownDescription: "Ciphers used when a TLS configuration does not set any",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"protocol_min_default": schema.StringAttribute{
+				MarkdownDescription: "Minimum TLS protocol version used by default",
+				Computed:            true,
+			},
+			"protocol_max_default": schema.StringAttribute{
+				MarkdownDescription: "Maximum TLS protocol version used by default",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *TLSConfigurationDefaultsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(assetsv1.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *assetsv1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TLSConfigurationDefaultsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "Reading TLSConfigurationDefaults")
+
+	// Read Terraform configuration data into the model
+	var state TLSConfigurationDefaultsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var defaults *assetsv1.TLSConfigurationDefault
+	var err error
+	if state.SelfHosted.ValueBool() {
+		defaults, err = d.client.TLSConfiguration().DefaultSelfHosted(ctx, &emptypb.Empty{})
+	} else {
+		defaults, err = d.client.TLSConfiguration().Default(ctx, &emptypb.Empty{})
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read TLS configuration defaults, got error: %s", err))
+		return
+	}
+
+	state.CiphersAvailable = append([]string{}, defaults.GetCiphersAvailable()...)
+	state.CiphersDefault = append([]string{}, defaults.GetCiphersDefault()...)
+	state.ProtocolMinDefault = types.StringValue(defaults.GetProtocolMinDefault().String())
+	state.ProtocolMaxDefault = types.StringValue(defaults.GetProtocolMaxDefault().String())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
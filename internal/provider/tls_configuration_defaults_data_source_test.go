@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTLSConfigurationDefaultsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccTLSConfigurationDefaultsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.ubika_tls_configuration_defaults.test", "ciphers_available.#"),
+					resource.TestCheckResourceAttrSet("data.ubika_tls_configuration_defaults.test", "protocol_min_default"),
+					resource.TestCheckResourceAttrSet("data.ubika_tls_configuration_defaults.self_hosted", "protocol_max_default"),
+				),
+			},
+		},
+	})
+}
+
+const testAccTLSConfigurationDefaultsDataSourceConfig = `
+data "ubika_tls_configuration_defaults" "test" {
+}
+
+data "ubika_tls_configuration_defaults" "self_hosted" {
+  self_hosted = true
+}
+`
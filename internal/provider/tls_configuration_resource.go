@@ -0,0 +1,419 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+	"github.com/ubikasec/terraform-provider-ubika/internal/crypto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TLSConfigurationResource{}
+var _ resource.ResourceWithImportState = &TLSConfigurationResource{}
+var _ resource.ResourceWithValidateConfig = &TLSConfigurationResource{}
+var _ resource.ResourceWithModifyPlan = &TLSConfigurationResource{}
+
+func NewTLSConfigurationResource() resource.Resource {
+	return &TLSConfigurationResource{}
+}
+
+// TLSConfigurationResource defines the resource implementation.
+type TLSConfigurationResource struct {
+	client assetsv1.Client
+}
+
+func (r *TLSConfigurationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tls_configuration"
+}
+
+func (r *TLSConfigurationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "TLSConfiguration resource",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of this resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"metadata": GetObjectMetaResource(),
+			"spec": schema.SingleNestedAttribute{
+				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"protocol_min": schema.StringAttribute{
+						MarkdownDescription: "Minimum TLS protocol version (DEFAULT, TLS_1_0, TLS_1_1, TLS_1_2 or TLS_1_3)",
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString(crypto.TLSProtocol_DEFAULT.String()),
+						Validators: []validator.String{
+							newEnumValidator(crypto.TLSProtocol_Enum_value),
+						},
+					},
+					"protocol_max": schema.StringAttribute{
+						MarkdownDescription: "Maximum TLS protocol version (DEFAULT, TLS_1_0, TLS_1_1, TLS_1_2 or TLS_1_3)",
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString(crypto.TLSProtocol_DEFAULT.String()),
+						Validators: []validator.String{
+							newEnumValidator(crypto.TLSProtocol_Enum_value),
+						},
+					},
+					"ciphers": schema.SetAttribute{
+						MarkdownDescription: "Ciphers for TLS 1.0 to 1.2, must be part of the ciphers available on the platform",
+						Optional:            true,
+						Computed:            true,
+						ElementType:         types.StringType,
+						PlanModifiers: []planmodifier.Set{
+							setplanmodifier.UseStateForUnknown(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *TLSConfigurationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(assetsv1.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *assetsv1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TLSConfigurationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var protocolMin, protocolMax types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("spec").AtName("protocol_min"), &protocolMin)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("spec").AtName("protocol_max"), &protocolMax)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if protocolMin.IsNull() || protocolMin.IsUnknown() || protocolMax.IsNull() || protocolMax.IsUnknown() {
+		return
+	}
+
+	// unknown names are reported by the attribute validators
+	minVersion, ok := crypto.TLSProtocol_Enum_value[protocolMin.ValueString()]
+	if !ok {
+		return
+	}
+	maxVersion, ok := crypto.TLSProtocol_Enum_value[protocolMax.ValueString()]
+	if !ok {
+		return
+	}
+
+	// DEFAULT lets the platform choose the version
+	if crypto.TLSProtocol_Enum(minVersion) == crypto.TLSProtocol_DEFAULT || crypto.TLSProtocol_Enum(maxVersion) == crypto.TLSProtocol_DEFAULT {
+		return
+	}
+
+	if minVersion > maxVersion {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("spec").AtName("protocol_min"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("spec.protocol_min (%s) must not be greater than spec.protocol_max (%s).", protocolMin.ValueString(), protocolMax.ValueString()),
+		)
+	}
+}
+
+// ModifyPlan checks the ciphers against the ciphers available on the platform.
+func (r *TLSConfigurationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do on destroy or if the provider is not configured yet
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var ciphers types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("spec").AtName("ciphers"), &ciphers)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if ciphers.IsNull() || ciphers.IsUnknown() {
+		return
+	}
+
+	var wanted []types.String
+	resp.Diagnostics.Append(ciphers.ElementsAs(ctx, &wanted, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	defaults, err := r.client.TLSConfiguration().Default(ctx, &emptypb.Empty{})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get available TLS ciphers, got error: %s", err))
+		return
+	}
+
+	available := make(map[string]bool, len(defaults.GetCiphersAvailable()))
+	for _, cipher := range defaults.GetCiphersAvailable() {
+		available[cipher] = true
+	}
+
+	for _, cipher := range wanted {
+		if cipher.IsUnknown() || available[cipher.ValueString()] {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("spec").AtName("ciphers"),
+			"Invalid Attribute Value",
+			fmt.Sprintf("Cipher %q is not available, must be one of: %s", cipher.ValueString(), strings.Join(defaults.GetCiphersAvailable(), ", ")),
+		)
+	}
+}
+
+// tlsConfigurationToProto converts the plan to the protobuf resource. Unlike
+// the generated ToProto, unknown or null ciphers are sent empty, the server
+// then fills in its default ciphers.
+func tlsConfigurationToProto(ctx context.Context, plan *assetsv1.TLSConfigurationResourceTFModel) (*assetsv1.TLSConfiguration, diag.Diagnostics) {
+	r := assetsv1.NewTLSConfiguration("")
+
+	var metadata *metav1.ObjectMetaResourceTFModel
+	if diags := plan.Metadata.As(ctx, &metadata, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true}); diags.HasError() {
+		return r, diags
+	}
+	meta, diags := metadata.ToProto(ctx)
+	if diags.HasError() {
+		return r, diags
+	}
+	r.Metadata = meta
+
+	var spec *assetsv1.TLSConfigurationSpecResourceTFModel
+	if diags := plan.Spec.As(ctx, &spec, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true}); diags.HasError() || spec == nil {
+		return r, diags
+	}
+	if spec.Ciphers.IsNull() || spec.Ciphers.IsUnknown() {
+		spec.Ciphers = types.SetValueMust(types.StringType, []attr.Value{})
+	}
+	r.Spec, diags = spec.ToProto(ctx)
+	return r, diags
+}
+
+func (r *TLSConfigurationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "Creating TLSConfiguration")
+
+	// Read Terraform plan data into the model
+	var plan *assetsv1.TLSConfigurationResourceTFModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// convert plan to protobuf resource
+	tlsConfiguration, diags := tlsConfigurationToProto(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the resource
+	tlsConfiguration, err := r.client.TLSConfiguration().Create(ctx, tlsConfiguration)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create TLS configuration, got error: %s", err))
+		return
+	}
+
+	// generate state from protobuf resource
+	var state assetsv1.TLSConfigurationResourceModel
+	_, err = state.FromProto(tlsConfiguration)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get state from TLS configuration, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created a TLS configuration")
+
+	// Save state data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *TLSConfigurationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading TLSConfiguration")
+
+	// Read Terraform prior state data into the model
+	var state *assetsv1.TLSConfigurationResourceTFModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	newState, diags := r.read(ctx, state.Metadata, nil)
+	if isNotFoundDiagnostics(diags) {
+		tflog.Warn(ctx, "TLS configuration not found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *TLSConfigurationResource) read(ctx context.Context, metaObjValue basetypes.ObjectValue, meta *metav1.ObjectMetaResourceTFModel) (assetsv1.TLSConfigurationResourceModel, diag.Diagnostics) {
+	// get metadata from state
+	if meta == nil {
+		if diags := metaObjValue.As(ctx, &meta, basetypes.ObjectAsOptions{}); diags.HasError() {
+			return assetsv1.TLSConfigurationResourceModel{}, diags
+		}
+	}
+
+	tlsConfiguration, err := r.client.TLSConfiguration().Get(ctx, &metav1.GetOptions{
+		Name:      meta.Name.ValueString(),
+		Namespace: meta.Namespace.ValueString(),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return assetsv1.TLSConfigurationResourceModel{}, []diag.Diagnostic{notFoundDiagnostic("TLS configuration", meta)}
+		}
+		return assetsv1.TLSConfigurationResourceModel{}, []diag.Diagnostic{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to read TLS configuration %s/%s, got error: %s", meta.Name.ValueString(), meta.Namespace.ValueString(), err))}
+	}
+
+	// update state from protobuf resource
+	var state assetsv1.TLSConfigurationResourceModel
+	_, err = state.FromProto(tlsConfiguration)
+	if err != nil {
+		return assetsv1.TLSConfigurationResourceModel{}, []diag.Diagnostic{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to get state from TLS configuration %s/%s, got error: %s", meta.Name.ValueString(), meta.Namespace.ValueString(), err))}
+	}
+	return state, nil
+}
+
+func (r *TLSConfigurationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var plan *assetsv1.TLSConfigurationResourceTFModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// convert plan to protobuf resource
+	tlsConfiguration, diags := tlsConfigurationToProto(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// send the prior version so that concurrent changes are detected
+	priorMeta := tlsConfiguration.GetMetadata()
+	resp.Diagnostics.Append(setPriorVersion(ctx, r.client, req.State, priorMeta)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only the changed fields are sent when partial updates are supported
+	svc := r.client.TLSConfiguration()
+	tlsConfiguration, err := updateObject(ctx, r.client, svc, req, tlsConfiguration, svc.Update)
+	if err != nil {
+		if isConflict(err, priorMeta.GetVersion() != 0) {
+			resp.Diagnostics.Append(conflictDiagnostic("TLS configuration", priorMeta))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update TLS configuration, got error: %s", err))
+		return
+	}
+
+	// generate state from protobuf resource
+	var state assetsv1.TLSConfigurationResourceModel
+	_, err = state.FromProto(tlsConfiguration)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get state from TLS configuration, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *TLSConfigurationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "Deleting TLSConfiguration")
+	var plan *assetsv1.TLSConfigurationResourceTFModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var meta metav1.ObjectMetaResourceTFModel
+	if diags := plan.Metadata.As(ctx, &meta, basetypes.ObjectAsOptions{}); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	_, err := r.client.TLSConfiguration().Delete(ctx, &metav1.DeleteOptions{
+		Name:      meta.Name.ValueString(),
+		Namespace: meta.Namespace.ValueString(),
+	})
+	if isNotFound(err) {
+		// already deleted
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete TLS configuration, got error: %s", err))
+		return
+	}
+}
+
+func (r *TLSConfigurationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	var name, namespace string
+	if len(parts) == 2 {
+		namespace = parts[0]
+		name = parts[1]
+	} else {
+		resp.Diagnostics.AddError("Inexpected input", "A namespace is required, ID must be in the form 'namespace/resource-name'")
+	}
+
+	meta := metav1.ObjectMetaResourceTFModel{
+		Name:      types.StringValue(name),
+		Namespace: types.StringValue(namespace),
+	}
+	state, diags := r.read(ctx, basetypes.ObjectValue{}, &meta)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+)
+
+func TestAccTLSConfigurationResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Invalid protocol range
+			{
+				Config:      testAccTLSConfigurationResourceConfig("tf-acc-test", "tf-acc-tests", "TLS_1_3", "TLS_1_2"),
+				ExpectError: regexp.MustCompile("must not be greater than"),
+			},
+			// Unknown protocol
+			{
+				Config:      testAccTLSConfigurationResourceConfig("tf-acc-test", "tf-acc-tests", "TLSv1.2", "TLS_1_3"),
+				ExpectError: regexp.MustCompile("value must be one of"),
+			},
+			// Create and Read testing
+			{
+				Config: testAccTLSConfigurationResourceConfig("tf-acc-test", "tf-acc-tests", "TLS_1_2", "TLS_1_3"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ubika_tls_configuration.test", "metadata.name", "tf-acc-test"),
+					resource.TestCheckResourceAttr("ubika_tls_configuration.test", "spec.protocol_min", "TLS_1_2"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "ubika_tls_configuration.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.namespace", "defaulted"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccTLSConfigurationResourceConfig("tf-acc-test", "tf-acc-tests", "TLS_1_3", "TLS_1_3"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ubika_tls_configuration.test", "metadata.namespace", "tf-acc-tests"),
+					resource.TestCheckResourceAttr("ubika_tls_configuration.test", "spec.protocol_min", "TLS_1_3"),
+				),
+			},
+			// // Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccTLSConfigurationResourceConfig(name string, namespace string, protocolMin string, protocolMax string) string {
+	return fmt.Sprintf(`
+resource "ubika_tls_configuration" "test" {
+  metadata = {
+    name = %[1]q
+    namespace = %[2]q
+  }
+  spec = {
+	protocol_min = %[3]q
+	protocol_max = %[4]q
+  }
+}
+`, name, namespace, protocolMin, protocolMax)
+}
+
+func TestTLSConfigurationToProtoCiphers(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp fwresource.SchemaResponse
+	NewTLSConfigurationResource().Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	tlsConfiguration := assetsv1.NewTLSConfiguration("tf-acc-test")
+	tlsConfiguration.Metadata.Namespace = "tf-acc-tests"
+	tlsConfiguration.Spec = &assetsv1.TLSConfigurationSpec{Ciphers: []string{"ECDHE-RSA-AES128-GCM-SHA256"}}
+
+	var model assetsv1.TLSConfigurationResourceModel
+	_, err := model.FromProto(tlsConfiguration)
+	require.NoError(t, err)
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	diags := state.Set(ctx, &model)
+	require.False(t, diags.HasError(), diags)
+
+	toProto := func(ciphers tftypes.Value) *assetsv1.TLSConfiguration {
+		raw, err := tftypes.Transform(state.Raw, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+			if p.Equal(tftypes.NewAttributePath().WithAttributeName("spec").WithAttributeName("ciphers")) {
+				return ciphers, nil
+			}
+			return v, nil
+		})
+		require.NoError(t, err)
+
+		var plan *assetsv1.TLSConfigurationResourceTFModel
+		diags := tfsdk.Plan{Schema: state.Schema, Raw: raw}.Get(ctx, &plan)
+		require.False(t, diags.HasError(), diags)
+		r, diags := tlsConfigurationToProto(ctx, plan)
+		require.False(t, diags.HasError(), diags)
+		return r
+	}
+
+	ciphersType := tftypes.Set{ElementType: tftypes.String}
+
+	// the ciphers not set in the configuration are filled in by the server
+	assert.Empty(t, toProto(tftypes.NewValue(ciphersType, tftypes.UnknownValue)).GetSpec().GetCiphers())
+	assert.Empty(t, toProto(tftypes.NewValue(ciphersType, nil)).GetSpec().GetCiphers())
+
+	r := toProto(tftypes.NewValue(ciphersType, []tftypes.Value{tftypes.NewValue(tftypes.String, "ECDHE-RSA-AES128-GCM-SHA256")}))
+	assert.Equal(t, []string{"ECDHE-RSA-AES128-GCM-SHA256"}, r.GetSpec().GetCiphers())
+	assert.Equal(t, "tf-acc-tests", r.GetMetadata().GetNamespace())
+}
@@ -0,0 +1,341 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TLSCSRCertificateResource{}
+
+func NewTLSCSRCertificateResource() resource.Resource {
+	return &TLSCSRCertificateResource{}
+}
+
+// TLSCSRCertificateResource defines the resource implementation. It is not
+// importable as the TLS material it creates does not reference its CSR.
+type TLSCSRCertificateResource struct {
+	client assetsv1.Client
+}
+
+// tlsCSRCertificateResourceModel is the state model of the resource. The
+// metadata is the one of the TLS material created from the certificate.
+type tlsCSRCertificateResourceModel struct {
+	Id          string                              `tfsdk:"id"`
+	Metadata    *metav1.ObjectMetaResourceModel     `tfsdk:"metadata"`
+	Spec        *tlsCSRCertificateSpecResourceModel `tfsdk:"spec"`
+	TLSMaterial string                              `tfsdk:"tls_material"`
+}
+
+type tlsCSRCertificateSpecResourceModel struct {
+	Csr         string  `tfsdk:"csr"`
+	Certificate string  `tfsdk:"certificate"`
+	Chain       *string `tfsdk:"chain"`
+}
+
+// tlsCSRCertificateResourceTFModel is the plan model of the resource.
+type tlsCSRCertificateResourceTFModel struct {
+	Id          types.String `tfsdk:"id"`
+	Metadata    types.Object `tfsdk:"metadata"`
+	Spec        types.Object `tfsdk:"spec"`
+	TLSMaterial types.String `tfsdk:"tls_material"`
+}
+
+// ToProto converts the model to the corresponding protobuf struct
+func (m *tlsCSRCertificateResourceTFModel) ToProto(ctx context.Context) (*assetsv1.CSRCertificate, diag.Diagnostics) {
+	r := assetsv1.NewCSRCertificate("")
+
+	var metadata *metav1.ObjectMetaResourceTFModel
+	if diags := m.Metadata.As(ctx, &metadata, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true}); diags.HasError() {
+		return r, diags
+	}
+	if MetadataTmp, diags := metadata.ToProto(ctx); diags.HasError() {
+		return r, diags
+	} else {
+		r.Metadata = MetadataTmp
+	}
+
+	// the spec attributes match the ones of the generated model
+	var spec *assetsv1.CSRCertificateSpecResourceTFModel
+	if diags := m.Spec.As(ctx, &spec, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true}); diags.HasError() {
+		return r, diags
+	}
+	if SpecTmp, diags := spec.ToProto(ctx); diags.HasError() {
+		return r, diags
+	} else if SpecTmp != nil {
+		r.Spec = SpecTmp
+	}
+	return r, nil
+}
+
+func (r *TLSCSRCertificateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tls_csr_certificate"
+}
+
+func (r *TLSCSRCertificateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "TLS CSR certificate resource, uploads the certificate signed from a `ubika_tls_csr` and turns it into a TLS material named after `metadata.name`",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of this resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"metadata": GetObjectMetaResource(),
+			"spec": schema.SingleNestedAttribute{
+				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"csr": schema.StringAttribute{
+						MarkdownDescription: "Name of the CSR the certificate was signed from",
+						Required:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"certificate": schema.StringAttribute{
+						MarkdownDescription: "PEM encoded signed certificate",
+						Required:            true,
+					},
+					"chain": schema.StringAttribute{
+						MarkdownDescription: "PEM encoded intermediate certificates",
+						Optional:            true,
+					},
+				},
+			},
+			"tls_material": schema.StringAttribute{
+				MarkdownDescription: "Name of the resulting TLS material, to be used as `tls_material` of an asset",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *TLSCSRCertificateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(assetsv1.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *assetsv1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TLSCSRCertificateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "Creating TLSCSRCertificate")
+
+	// Read Terraform plan data into the model
+	var plan *tlsCSRCertificateResourceTFModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// convert plan to protobuf resource
+	csrCertificate, diags := plan.ToProto(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the resource
+	tlsMaterial, err := r.client.TLSConfiguration().UpdateCSRCertificate(ctx, csrCertificate)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create TLS CSR certificate, got error: %s", err))
+		return
+	}
+
+	// generate state from protobuf resource
+	state, diags := newTLSCSRCertificateState(ctx, tlsMaterial, plan.Spec)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a TLS CSR certificate")
+
+	// Save state data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *TLSCSRCertificateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading TLSCSRCertificate")
+
+	// Read Terraform prior state data into the model
+	var state *tlsCSRCertificateResourceTFModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var meta *metav1.ObjectMetaResourceTFModel
+	resp.Diagnostics.Append(state.Metadata.As(ctx, &meta, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tlsMaterial, err := r.client.TLSConfiguration().GetTLSMaterial(ctx, &metav1.GetOptions{
+		Name:      meta.Name.ValueString(),
+		Namespace: meta.Namespace.ValueString(),
+	})
+	if isNotFound(err) {
+		tflog.Warn(ctx, "TLS material not found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read TLS material %s/%s, got error: %s", meta.Name.ValueString(), meta.Namespace.ValueString(), err))
+		return
+	}
+
+	newState, diags := newTLSCSRCertificateState(ctx, tlsMaterial, state.Spec)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *TLSCSRCertificateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var plan *tlsCSRCertificateResourceTFModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// convert plan to protobuf resource
+	csrCertificate, diags := plan.ToProto(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// send the prior version so that concurrent changes are detected
+	priorMeta := csrCertificate.GetMetadata()
+	resp.Diagnostics.Append(setPriorVersion(ctx, r.client, req.State, priorMeta)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// a renewed certificate of the same CSR replaces the one of the material
+	tlsMaterial, err := r.client.TLSConfiguration().UpdateCSRCertificate(ctx, csrCertificate)
+	if err != nil {
+		if isConflict(err, priorMeta.GetVersion() != 0) {
+			resp.Diagnostics.Append(conflictDiagnostic("TLS material", priorMeta))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update TLS CSR certificate, got error: %s", err))
+		return
+	}
+
+	// generate state from protobuf resource
+	state, diags := newTLSCSRCertificateState(ctx, tlsMaterial, plan.Spec)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *TLSCSRCertificateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "Deleting TLSCSRCertificate")
+	var plan *tlsCSRCertificateResourceTFModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var meta metav1.ObjectMetaResourceTFModel
+	if diags := plan.Metadata.As(ctx, &meta, basetypes.ObjectAsOptions{}); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	_, err := r.client.TLSConfiguration().DeleteTLSMaterial(ctx, &metav1.DeleteOptions{
+		Name:      meta.Name.ValueString(),
+		Namespace: meta.Namespace.ValueString(),
+	})
+	if isNotFound(err) {
+		// already deleted
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete TLS material, got error: %s", err))
+		return
+	}
+}
+
+// newTLSCSRCertificateState generates the state from the TLS material created
+// from the certificate. The CSR name is not part of the material and is kept
+// from the prior spec, as is an unset chain.
+func newTLSCSRCertificateState(ctx context.Context, tlsMaterial *assetsv1.TLSMaterial, prior basetypes.ObjectValue) (tlsCSRCertificateResourceModel, diag.Diagnostics) {
+	var generated assetsv1.TLSMaterialResourceModel
+	if _, err := generated.FromProto(tlsMaterial); err != nil {
+		return tlsCSRCertificateResourceModel{}, []diag.Diagnostic{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to get state from TLS material, got error: %s", err))}
+	}
+
+	state := tlsCSRCertificateResourceModel{
+		Id:          generated.Id,
+		Metadata:    generated.Metadata,
+		Spec:        &tlsCSRCertificateSpecResourceModel{},
+		TLSMaterial: tlsMaterial.GetMetadata().GetName(),
+	}
+	if generated.Spec != nil {
+		state.Spec.Certificate = generated.Spec.Certificate
+		if generated.Spec.Chain != "" {
+			state.Spec.Chain = &generated.Spec.Chain
+		}
+	}
+
+	if prior.IsNull() || prior.IsUnknown() {
+		return state, nil
+	}
+
+	var priorSpec assetsv1.CSRCertificateSpecResourceTFModel
+	if diags := prior.As(ctx, &priorSpec, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return tlsCSRCertificateResourceModel{}, diags
+	}
+	state.Spec.Csr = priorSpec.Csr.ValueString()
+	if state.Spec.Chain == nil && !priorSpec.Chain.IsNull() {
+		state.Spec.Chain = priorSpec.Chain.ValueStringPointer()
+	}
+
+	return state, nil
+}
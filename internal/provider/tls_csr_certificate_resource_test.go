@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTLSCSRCertificateResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"tls": {Source: "hashicorp/tls"},
+		},
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccTLSCSRCertificateResourceConfig("tf-acc-test", "tf-acc-tests", 24),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ubika_tls_csr_certificate.test", "metadata.name", "tf-acc-test"),
+					resource.TestCheckResourceAttr("ubika_tls_csr_certificate.test", "tls_material", "tf-acc-test"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: testAccTLSCSRCertificateResourceConfig("tf-acc-test", "tf-acc-tests", 48),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("ubika_tls_csr_certificate.test", "spec.certificate", "tls_locally_signed_cert.test", "cert_pem"),
+				),
+			},
+			// // Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccTLSCSRCertificateResourceConfig(name string, namespace string, validityHours int) string {
+	return testAccTLSCSRResourceConfig(name, namespace) + fmt.Sprintf(`
+resource "tls_private_key" "ca" {
+  algorithm = "ECDSA"
+}
+
+resource "tls_self_signed_cert" "ca" {
+  private_key_pem       = tls_private_key.ca.private_key_pem
+  is_ca_certificate     = true
+  validity_period_hours = 48
+  allowed_uses          = ["cert_signing"]
+  subject {
+    common_name = "tf-acc-test CA"
+  }
+}
+
+resource "tls_locally_signed_cert" "test" {
+  cert_request_pem      = ubika_tls_csr.test.spec.csr
+  ca_private_key_pem    = tls_private_key.ca.private_key_pem
+  ca_cert_pem           = tls_self_signed_cert.ca.cert_pem
+  validity_period_hours = %[3]d
+  allowed_uses          = ["digital_signature", "server_auth"]
+}
+
+resource "ubika_tls_csr_certificate" "test" {
+  metadata = {
+    name = %[1]q
+    namespace = %[2]q
+  }
+  spec = {
+	csr = ubika_tls_csr.test.metadata.name
+	certificate = tls_locally_signed_cert.test.cert_pem
+	chain = tls_self_signed_cert.ca.cert_pem
+  }
+}
+`, name, namespace, validityHours)
+}
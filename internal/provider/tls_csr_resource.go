@@ -0,0 +1,350 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TLSCSRResource{}
+var _ resource.ResourceWithImportState = &TLSCSRResource{}
+
+func NewTLSCSRResource() resource.Resource {
+	return &TLSCSRResource{}
+}
+
+// TLSCSRResource defines the resource implementation.
+type TLSCSRResource struct {
+	client assetsv1.Client
+}
+
+// tlsCSRResourceModel is the state model of the resource. Its spec merges the
+// asset of the assetsv1.CSRCreateSpec with the PEM CSR of the assetsv1.CSRSpec.
+type tlsCSRResourceModel struct {
+	Id       string                           `tfsdk:"id"`
+	Metadata *metav1.ObjectMetaResourceModel  `tfsdk:"metadata"`
+	Spec     *tlsCSRSpecResourceModel         `tfsdk:"spec"`
+	Status   *assetsv1.CSRStatusResourceModel `tfsdk:"status"`
+}
+
+type tlsCSRSpecResourceModel struct {
+	Asset string `tfsdk:"asset"`
+	Csr   string `tfsdk:"csr"`
+}
+
+// FromProto imports field values from protobuf message
+func (m *tlsCSRResourceModel) FromProto(r *assetsv1.CSR) (_ *tlsCSRResourceModel, err error) {
+	var generated assetsv1.CSRResourceModel
+	if _, err = generated.FromProto(r); err != nil {
+		return m, err
+	}
+
+	m.Id = generated.Id
+	m.Metadata = generated.Metadata
+	m.Status = generated.Status
+	m.Spec = &tlsCSRSpecResourceModel{
+		Asset: r.GetStatus().GetAsset(),
+		Csr:   r.GetSpec().GetCsr(),
+	}
+	return m, nil
+}
+
+// tlsCSRResourceTFModel is the plan model of the resource.
+type tlsCSRResourceTFModel struct {
+	Id       types.String `tfsdk:"id"`
+	Metadata types.Object `tfsdk:"metadata"`
+	Spec     types.Object `tfsdk:"spec"`
+	Status   types.Object `tfsdk:"status"`
+}
+
+type tlsCSRSpecResourceTFModel struct {
+	Asset types.String `tfsdk:"asset"`
+	Csr   types.String `tfsdk:"csr"`
+}
+
+// ToProto converts the model to the corresponding protobuf struct
+func (m *tlsCSRResourceTFModel) ToProto(ctx context.Context) (*assetsv1.CSRCreate, diag.Diagnostics) {
+	r := assetsv1.NewCSRCreate("")
+
+	var metadata *metav1.ObjectMetaResourceTFModel
+	if diags := m.Metadata.As(ctx, &metadata, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true}); diags.HasError() {
+		return r, diags
+	}
+	if MetadataTmp, diags := metadata.ToProto(ctx); diags.HasError() {
+		return r, diags
+	} else {
+		r.Metadata = MetadataTmp
+	}
+
+	var spec *tlsCSRSpecResourceTFModel
+	if diags := m.Spec.As(ctx, &spec, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true}); diags.HasError() {
+		return r, diags
+	}
+	if spec != nil && !spec.Asset.IsNull() && !spec.Asset.IsUnknown() {
+		r.Spec.Asset = spec.Asset.ValueString()
+	}
+	return r, nil
+}
+
+func (r *TLSCSRResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tls_csr"
+}
+
+func (r *TLSCSRResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "TLS CSR resource, a certificate signing request for an asset whose private key is kept by the platform. Use `ubika_tls_csr_certificate` to upload the signed certificate.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of this resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"metadata": GetObjectMetaResource(),
+			"spec": schema.SingleNestedAttribute{
+				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"asset": schema.StringAttribute{
+						MarkdownDescription: "Name of the asset the CSR is created for, the hostnames of the asset are used as subject alternative names",
+						Required:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"csr": schema.StringAttribute{
+						MarkdownDescription: "PEM encoded certificate signing request",
+						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+				},
+			},
+			"status": schema.SingleNestedAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"asset": schema.StringAttribute{
+						MarkdownDescription: "Name of the asset of the CSR",
+						Computed:            true,
+					},
+					"hostnames": schema.SetAttribute{
+						MarkdownDescription: "Hostnames requested in the CSR",
+						Computed:            true,
+						ElementType:         types.StringType,
+					},
+					"mode": schema.StringAttribute{
+						MarkdownDescription: "TLS mode of the CSR",
+						Computed:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *TLSCSRResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(assetsv1.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *assetsv1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TLSCSRResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "Creating TLSCSR")
+
+	// Read Terraform plan data into the model
+	var plan *tlsCSRResourceTFModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// convert plan to protobuf resource
+	csrCreate, diags := plan.ToProto(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the resource
+	csr, err := r.client.TLSConfiguration().CreateCSR(ctx, csrCreate)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create TLS CSR, got error: %s", err))
+		return
+	}
+
+	// generate state from protobuf resource
+	var state tlsCSRResourceModel
+	_, err = state.FromProto(csr)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get state from TLS CSR, got error: %s", err))
+		return
+	}
+	// the asset is only known from the plan if the API does not report it
+	if state.Spec.Asset == "" {
+		state.Spec.Asset = csrCreate.GetSpec().GetAsset()
+	}
+
+	tflog.Trace(ctx, "created a TLS CSR")
+
+	// Save state data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *TLSCSRResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading TLSCSR")
+
+	// Read Terraform prior state data into the model
+	var state *tlsCSRResourceTFModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	newState, diags := r.read(ctx, state.Metadata, nil)
+	if isNotFoundDiagnostics(diags) {
+		tflog.Warn(ctx, "TLS CSR not found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var spec tlsCSRSpecResourceTFModel
+	resp.Diagnostics.Append(state.Spec.As(ctx, &spec, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if newState.Spec.Asset == "" {
+		newState.Spec.Asset = spec.Asset.ValueString()
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *TLSCSRResource) read(ctx context.Context, metaObjValue basetypes.ObjectValue, meta *metav1.ObjectMetaResourceTFModel) (tlsCSRResourceModel, diag.Diagnostics) {
+	// get metadata from state
+	if meta == nil {
+		if diags := metaObjValue.As(ctx, &meta, basetypes.ObjectAsOptions{}); diags.HasError() {
+			return tlsCSRResourceModel{}, diags
+		}
+	}
+
+	csr, err := r.client.TLSConfiguration().GetCSR(ctx, &metav1.GetOptions{
+		Name:      meta.Name.ValueString(),
+		Namespace: meta.Namespace.ValueString(),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return tlsCSRResourceModel{}, []diag.Diagnostic{notFoundDiagnostic("TLS CSR", meta)}
+		}
+		return tlsCSRResourceModel{}, []diag.Diagnostic{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to read TLS CSR %s/%s, got error: %s", meta.Name.ValueString(), meta.Namespace.ValueString(), err))}
+	}
+
+	// update state from protobuf resource
+	var state tlsCSRResourceModel
+	_, err = state.FromProto(csr)
+	if err != nil {
+		return tlsCSRResourceModel{}, []diag.Diagnostic{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to get state from TLS CSR %s/%s, got error: %s", meta.Name.ValueString(), meta.Namespace.ValueString(), err))}
+	}
+	return state, nil
+}
+
+// Update is never called as every attribute of a CSR requires a replacement.
+func (r *TLSCSRResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.AddError("Client Error", "Unable to update TLS CSR, a CSR can not be modified once created. Please report this issue to the provider developers.")
+}
+
+func (r *TLSCSRResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "Deleting TLSCSR")
+	var plan *tlsCSRResourceTFModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var meta metav1.ObjectMetaResourceTFModel
+	if diags := plan.Metadata.As(ctx, &meta, basetypes.ObjectAsOptions{}); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	_, err := r.client.TLSConfiguration().DeleteCSR(ctx, &metav1.DeleteOptions{
+		Name:      meta.Name.ValueString(),
+		Namespace: meta.Namespace.ValueString(),
+	})
+	if isNotFound(err) {
+		// already deleted
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete TLS CSR, got error: %s", err))
+		return
+	}
+}
+
+func (r *TLSCSRResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	var name, namespace string
+	if len(parts) == 2 {
+		namespace = parts[0]
+		name = parts[1]
+	} else {
+		resp.Diagnostics.AddError("Inexpected input", "A namespace is required, ID must be in the form 'namespace/resource-name'")
+	}
+
+	meta := metav1.ObjectMetaResourceTFModel{
+		Name:      types.StringValue(name),
+		Namespace: types.StringValue(namespace),
+	}
+	state, diags := r.read(ctx, basetypes.ObjectValue{}, &meta)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTLSCSRResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccTLSCSRResourceConfig("tf-acc-test", "tf-acc-tests"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ubika_tls_csr.test", "metadata.name", "tf-acc-test"),
+					resource.TestCheckResourceAttr("ubika_tls_csr.test", "spec.asset", "tf-acc-test"),
+					resource.TestCheckResourceAttrSet("ubika_tls_csr.test", "spec.csr"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "ubika_tls_csr.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.namespace", "defaulted"},
+			},
+			// // Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccTLSCSRResourceConfig(name string, namespace string) string {
+	return testAccAssetResourceConfig(name, namespace) + fmt.Sprintf(`
+resource "ubika_tls_csr" "test" {
+  metadata = {
+    name = %[1]q
+    namespace = %[2]q
+  }
+  spec = {
+	asset = ubika_asset.test.metadata.name
+  }
+}
+`, name, namespace)
+}
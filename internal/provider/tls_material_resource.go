@@ -0,0 +1,429 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TLSMaterialResource{}
+var _ resource.ResourceWithImportState = &TLSMaterialResource{}
+
+func NewTLSMaterialResource() resource.Resource {
+	return &TLSMaterialResource{}
+}
+
+// TLSMaterialResource defines the resource implementation.
+type TLSMaterialResource struct {
+	client assetsv1.Client
+}
+
+// tlsMaterialResourceModel is the state model of the resource. The key is
+// never returned by the API so it is kept from the plan, and the status uses
+// lower case attribute names with the timestamps as unix seconds.
+type tlsMaterialResourceModel struct {
+	Id       string                          `tfsdk:"id"`
+	Metadata *metav1.ObjectMetaResourceModel `tfsdk:"metadata"`
+	Spec     *tlsMaterialSpecResourceModel   `tfsdk:"spec"`
+	Status   *tlsMaterialStatusResourceModel `tfsdk:"status"`
+}
+
+type tlsMaterialSpecResourceModel struct {
+	Certificate string  `tfsdk:"certificate"`
+	Chain       *string `tfsdk:"chain"`
+	Key         string  `tfsdk:"key"`
+}
+
+type tlsMaterialStatusResourceModel struct {
+	Mode      string   `tfsdk:"mode"`
+	CN        string   `tfsdk:"cn"`
+	IssuerCN  string   `tfsdk:"issuer_cn"`
+	Hostnames []string `tfsdk:"hostnames"`
+	NotBefore int64    `tfsdk:"not_before"`
+	NotAfter  int64    `tfsdk:"not_after"`
+	UsedBy    string   `tfsdk:"used_by"`
+}
+
+// FromProto imports field values from protobuf message
+func (m *tlsMaterialStatusResourceModel) FromProto(r *assetsv1.TLSMaterialStatus) *tlsMaterialStatusResourceModel {
+	if m == nil {
+		m = new(tlsMaterialStatusResourceModel)
+	}
+	m.Mode = r.GetMode().String()
+	m.CN = r.GetCN()
+	m.IssuerCN = r.GetIssuer_CN()
+	m.Hostnames = r.GetHostnames()
+	if m.Hostnames == nil {
+		m.Hostnames = []string{}
+	}
+	m.NotBefore = r.GetNotBefore().GetSeconds()
+	m.NotAfter = r.GetNotAfter().GetSeconds()
+	m.UsedBy = r.GetUsedBy()
+	return m
+}
+
+// tlsMaterialResourceTFModel is the plan model of the resource.
+type tlsMaterialResourceTFModel struct {
+	Id       types.String `tfsdk:"id"`
+	Metadata types.Object `tfsdk:"metadata"`
+	Spec     types.Object `tfsdk:"spec"`
+	Status   types.Object `tfsdk:"status"`
+}
+
+// ToProto converts the model to the corresponding protobuf struct
+func (m *tlsMaterialResourceTFModel) ToProto(ctx context.Context) (*assetsv1.TLSManualCreate, diag.Diagnostics) {
+	r := assetsv1.NewTLSManualCreate("")
+
+	var metadata *metav1.ObjectMetaResourceTFModel
+	if diags := m.Metadata.As(ctx, &metadata, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true}); diags.HasError() {
+		return r, diags
+	}
+	if MetadataTmp, diags := metadata.ToProto(ctx); diags.HasError() {
+		return r, diags
+	} else {
+		r.Metadata = MetadataTmp
+	}
+
+	// the spec attributes match the ones of the generated model
+	var spec *assetsv1.TLSManualCreateSpecResourceTFModel
+	if diags := m.Spec.As(ctx, &spec, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true}); diags.HasError() {
+		return r, diags
+	}
+	if SpecTmp, diags := spec.ToProto(ctx); diags.HasError() {
+		return r, diags
+	} else if SpecTmp != nil {
+		r.Spec = SpecTmp
+	}
+	return r, nil
+}
+
+func (r *TLSMaterialResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tls_material"
+}
+
+func (r *TLSMaterialResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "TLSMaterial resource, a certificate and its private key to be used by assets with the `CUSTOM` TLS mode",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of this resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"metadata": GetObjectMetaResource(),
+			"spec": schema.SingleNestedAttribute{
+				Required: true,
+				Attributes: map[string]schema.Attribute{
+					"certificate": schema.StringAttribute{
+						MarkdownDescription: "PEM encoded certificate",
+						Required:            true,
+					},
+					"chain": schema.StringAttribute{
+						MarkdownDescription: "PEM encoded intermediate certificates",
+						Optional:            true,
+					},
+					"key": schema.StringAttribute{
+						MarkdownDescription: "PEM encoded private key of the certificate",
+						Required:            true,
+						Sensitive:           true,
+					},
+				},
+			},
+			"status": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"mode": schema.StringAttribute{
+						MarkdownDescription: "TLS mode of the material",
+						Computed:            true,
+					},
+					"cn": schema.StringAttribute{
+						MarkdownDescription: "Common name of the certificate",
+						Computed:            true,
+					},
+					"issuer_cn": schema.StringAttribute{
+						MarkdownDescription: "Common name of the certificate issuer",
+						Computed:            true,
+					},
+					"hostnames": schema.SetAttribute{
+						MarkdownDescription: "Hostnames covered by the certificate",
+						Computed:            true,
+						ElementType:         types.StringType,
+					},
+					"not_before": schema.Int64Attribute{
+						MarkdownDescription: "Start of the certificate validity, in seconds since the epoch",
+						Computed:            true,
+					},
+					"not_after": schema.Int64Attribute{
+						MarkdownDescription: "End of the certificate validity, in seconds since the epoch",
+						Computed:            true,
+					},
+					"used_by": schema.StringAttribute{
+						MarkdownDescription: "Asset using the material",
+						Computed:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *TLSMaterialResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(assetsv1.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *assetsv1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TLSMaterialResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	tflog.Info(ctx, "Creating TLSMaterial")
+
+	// Read Terraform plan data into the model
+	var plan *tlsMaterialResourceTFModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// convert plan to protobuf resource
+	tlsManual, diags := plan.ToProto(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the resource
+	tlsMaterial, err := r.client.TLSConfiguration().CreateManualTLS(ctx, tlsManual)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create TLS material, got error: %s", err))
+		return
+	}
+
+	// generate state from protobuf resource
+	state, diags := newTLSMaterialState(ctx, tlsMaterial, plan.Spec)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a TLS material")
+
+	// Save state data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *TLSMaterialResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Info(ctx, "Reading TLSMaterial")
+
+	// Read Terraform prior state data into the model
+	var state *tlsMaterialResourceTFModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	newState, diags := r.read(ctx, state.Metadata, nil, state.Spec)
+	if isNotFoundDiagnostics(diags) {
+		tflog.Warn(ctx, "TLS material not found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *TLSMaterialResource) read(ctx context.Context, metaObjValue basetypes.ObjectValue, meta *metav1.ObjectMetaResourceTFModel, prior basetypes.ObjectValue) (tlsMaterialResourceModel, diag.Diagnostics) {
+	// get metadata from state
+	if meta == nil {
+		if diags := metaObjValue.As(ctx, &meta, basetypes.ObjectAsOptions{}); diags.HasError() {
+			return tlsMaterialResourceModel{}, diags
+		}
+	}
+
+	tlsMaterial, err := r.client.TLSConfiguration().GetTLSMaterial(ctx, &metav1.GetOptions{
+		Name:      meta.Name.ValueString(),
+		Namespace: meta.Namespace.ValueString(),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return tlsMaterialResourceModel{}, []diag.Diagnostic{notFoundDiagnostic("TLS material", meta)}
+		}
+		return tlsMaterialResourceModel{}, []diag.Diagnostic{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to read TLS material %s/%s, got error: %s", meta.Name.ValueString(), meta.Namespace.ValueString(), err))}
+	}
+
+	// update state from protobuf resource
+	return newTLSMaterialState(ctx, tlsMaterial, prior)
+}
+
+func (r *TLSMaterialResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Read Terraform plan data into the model
+	var plan *tlsMaterialResourceTFModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// convert plan to protobuf resource
+	tlsManual, diags := plan.ToProto(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// send the prior version so that concurrent changes are detected
+	priorMeta := tlsManual.GetMetadata()
+	resp.Diagnostics.Append(setPriorVersion(ctx, r.client, req.State, priorMeta)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tlsMaterial, err := r.client.TLSConfiguration().UpdateManualTLS(ctx, tlsManual)
+	if err != nil {
+		if isConflict(err, priorMeta.GetVersion() != 0) {
+			resp.Diagnostics.Append(conflictDiagnostic("TLS material", priorMeta))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update TLS material, got error: %s", err))
+		return
+	}
+
+	// generate state from protobuf resource
+	state, diags := newTLSMaterialState(ctx, tlsMaterial, plan.Spec)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *TLSMaterialResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "Deleting TLSMaterial")
+	var plan *tlsMaterialResourceTFModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var meta metav1.ObjectMetaResourceTFModel
+	if diags := plan.Metadata.As(ctx, &meta, basetypes.ObjectAsOptions{}); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	_, err := r.client.TLSConfiguration().DeleteTLSMaterial(ctx, &metav1.DeleteOptions{
+		Name:      meta.Name.ValueString(),
+		Namespace: meta.Namespace.ValueString(),
+	})
+	if isNotFound(err) {
+		// already deleted
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete TLS material, got error: %s", err))
+		return
+	}
+}
+
+func (r *TLSMaterialResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	var name, namespace string
+	if len(parts) == 2 {
+		namespace = parts[0]
+		name = parts[1]
+	} else {
+		resp.Diagnostics.AddError("Inexpected input", "A namespace is required, ID must be in the form 'namespace/resource-name'")
+	}
+
+	meta := metav1.ObjectMetaResourceTFModel{
+		Name:      types.StringValue(name),
+		Namespace: types.StringValue(namespace),
+	}
+	state, diags := r.read(ctx, basetypes.ObjectValue{}, &meta, types.ObjectNull(nil))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// newTLSMaterialState generates the state from the protobuf resource. The key
+// is not returned by the API and is kept from the prior spec, as is an unset
+// chain.
+func newTLSMaterialState(ctx context.Context, tlsMaterial *assetsv1.TLSMaterial, prior basetypes.ObjectValue) (tlsMaterialResourceModel, diag.Diagnostics) {
+	var generated assetsv1.TLSMaterialResourceModel
+	if _, err := generated.FromProto(tlsMaterial); err != nil {
+		return tlsMaterialResourceModel{}, []diag.Diagnostic{diag.NewErrorDiagnostic("Client Error", fmt.Sprintf("Unable to get state from TLS material, got error: %s", err))}
+	}
+
+	state := tlsMaterialResourceModel{
+		Id:       generated.Id,
+		Metadata: generated.Metadata,
+		Spec:     &tlsMaterialSpecResourceModel{},
+		Status:   new(tlsMaterialStatusResourceModel).FromProto(tlsMaterial.GetStatus()),
+	}
+	if generated.Spec != nil {
+		state.Spec.Certificate = generated.Spec.Certificate
+		if generated.Spec.Chain != "" {
+			state.Spec.Chain = &generated.Spec.Chain
+		}
+	}
+
+	if prior.IsNull() || prior.IsUnknown() {
+		return state, nil
+	}
+
+	var priorSpec assetsv1.TLSManualCreateSpecResourceTFModel
+	if diags := prior.As(ctx, &priorSpec, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return tlsMaterialResourceModel{}, diags
+	}
+	state.Spec.Key = priorSpec.Key.ValueString()
+	if state.Spec.Chain == nil && !priorSpec.Chain.IsNull() {
+		state.Spec.Chain = priorSpec.Chain.ValueStringPointer()
+	}
+
+	return state, nil
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTLSMaterialResource(t *testing.T) {
+	cert, key := testAccTLSMaterialCertificate(t, "tf-acc-test.example.com")
+	newCert, newKey := testAccTLSMaterialCertificate(t, "tf-acc-test-updated.example.com")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccTLSMaterialResourceConfig("tf-acc-test", "tf-acc-tests", cert, key),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ubika_tls_material.test", "metadata.name", "tf-acc-test"),
+					resource.TestCheckResourceAttr("ubika_tls_material.test", "status.cn", "tf-acc-test.example.com"),
+					resource.TestCheckResourceAttrSet("ubika_tls_material.test", "status.not_after"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "ubika_tls_material.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.namespace", "defaulted", "spec.key"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccTLSMaterialResourceConfig("tf-acc-test", "tf-acc-tests", newCert, newKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ubika_tls_material.test", "metadata.namespace", "tf-acc-tests"),
+					resource.TestCheckResourceAttr("ubika_tls_material.test", "status.cn", "tf-acc-test-updated.example.com"),
+				),
+			},
+			// // Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccTLSMaterialCertificate generates a self-signed certificate and its
+// key, both PEM encoded.
+func testAccTLSMaterialCertificate(t *testing.T, cn string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     []string{cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}))
+}
+
+func testAccTLSMaterialResourceConfig(name string, namespace string, certificate string, key string) string {
+	return fmt.Sprintf(`
+resource "ubika_tls_material" "test" {
+  metadata = {
+    name = %[1]q
+    namespace = %[2]q
+  }
+  spec = {
+	certificate = %[3]q
+	key = %[4]q
+  }
+}
+`, name, namespace, certificate, key)
+}
@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TLSMaterialsDataSource{}
+
+func NewTLSMaterialsDataSource() datasource.DataSource {
+	return &TLSMaterialsDataSource{}
+}
+
+// TLSMaterialsDataSource defines the data source implementation.
+type TLSMaterialsDataSource struct {
+	client assetsv1.Client
+}
+
+// TLSMaterialsDataSourceModel describes the data source data model.
+type TLSMaterialsDataSourceModel struct {
+	Namespace         types.String            `tfsdk:"namespace"`
+	Hostname          types.String            `tfsdk:"hostname"`
+	Issuer            types.String            `tfsdk:"issuer"`
+	ExpiresWithinDays types.Int64             `tfsdk:"expires_within_days"`
+	Items             []tlsMaterialsItemModel `tfsdk:"items"`
+}
+
+// tlsMaterialsItemModel is a TLS material as listed by the API, without key.
+type tlsMaterialsItemModel struct {
+	Id       string                                 `tfsdk:"id"`
+	Metadata *metav1.ObjectMetaResourceModel        `tfsdk:"metadata"`
+	Spec     *assetsv1.TLSMaterialSpecResourceModel `tfsdk:"spec"`
+	Status   *tlsMaterialStatusResourceModel        `tfsdk:"status"`
+}
+
+func (d *TLSMaterialsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tls_materials"
+}
+
+func (d *TLSMaterialsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := listDataSourceAttributes(ctx, NewTLSMaterialResource())
+
+	// the key is never returned by the API
+	items := attributes["items"].(schema.ListNestedAttribute)
+	spec := items.NestedObject.Attributes["spec"].(schema.SingleNestedAttribute)
+	delete(spec.Attributes, "key")
+
+	attributes["namespace"] = schema.StringAttribute{
+		MarkdownDescription: "Namespace of the TLS materials to list",
+		Optional:            true,
+	}
+	attributes["hostname"] = schema.StringAttribute{
+		MarkdownDescription: "Only list the TLS materials covering this hostname, directly or with a wildcard",
+		Optional:            true,
+	}
+	attributes["issuer"] = schema.StringAttribute{
+		MarkdownDescription: "Only list the TLS materials issued by this common name",
+		Optional:            true,
+	}
+	attributes["expires_within_days"] = schema.Int64Attribute{
+		MarkdownDescription: "Only list the TLS materials expiring within this number of days, including the expired ones",
+		Optional:            true,
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "TLS materials data source, lists the TLS materials of a namespace matching the filters",
+
+		Attributes: attributes,
+	}
+}
+
+func (d *TLSMaterialsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(assetsv1.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *assetsv1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TLSMaterialsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "Reading TLSMaterials")
+
+	// Read Terraform configuration data into the model
+	var state TLSMaterialsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.ExpiresWithinDays.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("expires_within_days"), "Invalid Attribute Value", "expires_within_days must not be negative.")
+		return
+	}
+
+	tlsMaterials, err := d.client.TLSConfiguration().ListTLSMaterial(ctx, &metav1.ListOptions{
+		Namespace: state.Namespace.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list TLS materials, got error: %s", err))
+		return
+	}
+
+	// generate state from the protobuf resources matching the filters
+	now := time.Now()
+	state.Items = make([]tlsMaterialsItemModel, 0, len(tlsMaterials.GetItems()))
+	for _, tlsMaterial := range tlsMaterials.GetItems() {
+		if !state.matches(tlsMaterial.GetStatus(), now) {
+			continue
+		}
+
+		var generated assetsv1.TLSMaterialResourceModel
+		if _, err := generated.FromProto(tlsMaterial); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get state from TLS material %s/%s, got error: %s", tlsMaterial.GetMetadata().GetNamespace(), tlsMaterial.GetMetadata().GetName(), err))
+			return
+		}
+		state.Items = append(state.Items, tlsMaterialsItemModel{
+			Id:       generated.Id,
+			Metadata: generated.Metadata,
+			Spec:     generated.Spec,
+			Status:   new(tlsMaterialStatusResourceModel).FromProto(tlsMaterial.GetStatus()),
+		})
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// matches returns true if the status of a TLS material matches all the filters.
+func (m *TLSMaterialsDataSourceModel) matches(status *assetsv1.TLSMaterialStatus, now time.Time) bool {
+	if !m.Hostname.IsNull() && !tlsMaterialCoversHostname(status.GetHostnames(), m.Hostname.ValueString()) {
+		return false
+	}
+	if !m.Issuer.IsNull() && status.GetIssuer_CN() != m.Issuer.ValueString() {
+		return false
+	}
+	if !m.ExpiresWithinDays.IsNull() {
+		// the expiration of a material without certificate is unknown
+		if status.GetNotAfter() == nil {
+			return false
+		}
+		deadline := now.Add(time.Duration(m.ExpiresWithinDays.ValueInt64()) * 24 * time.Hour)
+		if status.GetNotAfter().AsTime().After(deadline) {
+			return false
+		}
+	}
+	return true
+}
+
+// tlsMaterialCoversHostname returns true if one of the hostnames of a
+// certificate is the given hostname or a wildcard covering it.
+func tlsMaterialCoversHostname(hostnames []string, hostname string) bool {
+	hostname = strings.ToLower(hostname)
+	for _, h := range hostnames {
+		h = strings.ToLower(h)
+		if h == hostname {
+			return true
+		}
+		if strings.HasPrefix(h, "*.") {
+			if label, parent, found := strings.Cut(hostname, "."); found && label != "" && parent == h[2:] {
+				return true
+			}
+		}
+	}
+	return false
+}
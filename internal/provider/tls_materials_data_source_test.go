@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAccTLSMaterialsDataSource(t *testing.T) {
+	cert, key := testAccTLSMaterialCertificate(t, "tf-acc-test.example.com")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccTLSMaterialsDataSourceConfig("tf-acc-test", "tf-acc-tests", cert, key),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.ubika_tls_materials.expiring", "items.*", map[string]string{
+						"metadata.name": "tf-acc-test",
+						"status.cn":     "tf-acc-test.example.com",
+					}),
+					resource.TestCheckResourceAttr("data.ubika_tls_materials.other_hostname", "items.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestTLSMaterialsDataSourceMatches(t *testing.T) {
+	now := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+	status := &assetsv1.TLSMaterialStatus{
+		Hostnames: []string{"example.com", "*.example.com"},
+		Issuer_CN: "Internal CA",
+		NotAfter:  timestamppb.New(now.Add(10 * 24 * time.Hour)),
+	}
+
+	testCases := []struct {
+		name   string
+		filter TLSMaterialsDataSourceModel
+		want   bool
+	}{
+		{"no filter", TLSMaterialsDataSourceModel{}, true},
+		{"hostname", TLSMaterialsDataSourceModel{Hostname: types.StringValue("example.com")}, true},
+		{"wildcard hostname", TLSMaterialsDataSourceModel{Hostname: types.StringValue("WWW.example.com")}, true},
+		{"wildcard one label only", TLSMaterialsDataSourceModel{Hostname: types.StringValue("a.b.example.com")}, false},
+		{"other hostname", TLSMaterialsDataSourceModel{Hostname: types.StringValue("example.org")}, false},
+		{"issuer", TLSMaterialsDataSourceModel{Issuer: types.StringValue("Internal CA")}, true},
+		{"other issuer", TLSMaterialsDataSourceModel{Issuer: types.StringValue("Public CA")}, false},
+		{"expires within", TLSMaterialsDataSourceModel{ExpiresWithinDays: types.Int64Value(30)}, true},
+		{"expires later", TLSMaterialsDataSourceModel{ExpiresWithinDays: types.Int64Value(5)}, false},
+		{"all filters", TLSMaterialsDataSourceModel{Hostname: types.StringValue("example.com"), Issuer: types.StringValue("Internal CA"), ExpiresWithinDays: types.Int64Value(10)}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.filter.matches(status, now))
+		})
+	}
+
+	// a material without expiration is not reported as expiring
+	status.NotAfter = nil
+	noFilter := TLSMaterialsDataSourceModel{}
+	assert.True(t, noFilter.matches(status, now))
+	expiring := TLSMaterialsDataSourceModel{ExpiresWithinDays: types.Int64Value(30)}
+	assert.False(t, expiring.matches(status, now))
+}
+
+func testAccTLSMaterialsDataSourceConfig(name string, namespace string, certificate string, key string) string {
+	return testAccTLSMaterialResourceConfig(name, namespace, certificate, key) + fmt.Sprintf(`
+data "ubika_tls_materials" "expiring" {
+  namespace           = %[1]q
+  hostname            = "tf-acc-test.example.com"
+  expires_within_days = 30
+
+  depends_on = [ubika_tls_material.test]
+}
+
+data "ubika_tls_materials" "other_hostname" {
+  namespace = %[1]q
+  hostname  = "other.example.com"
+
+  depends_on = [ubika_tls_material.test]
+}
+`, namespace)
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// enumValidator validates that a string attribute holds one of the names of a
+// protobuf enum, as found in the generated <Enum>_value maps.
+type enumValidator struct {
+	values map[string]int32
+}
+
+var _ validator.String = enumValidator{}
+
+func newEnumValidator(values map[string]int32) enumValidator {
+	return enumValidator{values: values}
+}
+
+func (v enumValidator) names() []string {
+	names := make([]string, 0, len(v.values))
+	for name := range v.values {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return v.values[names[i]] < v.values[names[j]] })
+	return names
+}
+
+func (v enumValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(v.names(), ", "))
+}
+
+func (v enumValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: `%s`", strings.Join(v.names(), "`, `"))
+}
+
+func (v enumValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, ok := v.values[req.ConfigValue.ValueString()]; !ok {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// ipAddressesValidator validates that each element of a set of strings is an
+// IPv4/IPv6 address or CIDR.
+type ipAddressesValidator struct{}
+
+var _ validator.Set = ipAddressesValidator{}
+
+func (v ipAddressesValidator) Description(ctx context.Context) string {
+	return "each value must be an IPv4/IPv6 address or CIDR"
+}
+
+func (v ipAddressesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipAddressesValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, element := range req.ConfigValue.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		if _, err := normalizeIPAddress(value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtSetValue(value),
+				"Invalid Attribute Value",
+				fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value.ValueString()),
+			)
+		}
+	}
+}
+
+// normalizeIPAddress returns the canonical form of an IP address or CIDR: host
+// bits of a CIDR are cleared and a single address CIDR is reduced to the
+// address, e.g. 10.0.0.1/24 becomes 10.0.0.0/24 and 10.0.0.1/32 becomes
+// 10.0.0.1.
+func normalizeIPAddress(s string) (string, error) {
+	if !strings.Contains(s, "/") {
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return "", err
+		}
+		return addr.String(), nil
+	}
+
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return "", err
+	}
+	if prefix.IsSingleIP() {
+		return prefix.Addr().String(), nil
+	}
+	return prefix.Masked().String(), nil
+}
+
+// normalizeIPAddresses normalizes, deduplicates and sorts a list of IP
+// addresses and CIDRs.
+func normalizeIPAddresses(addresses []string) ([]string, error) {
+	seen := make(map[string]bool, len(addresses))
+	normalized := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		n, err := normalizeIPAddress(address)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[n] {
+			seen[n] = true
+			normalized = append(normalized, n)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubikasec/terraform-provider-ubika/internal/crypto"
+)
+
+func TestEnumValidator(t *testing.T) {
+	testCases := []struct {
+		name    string
+		value   types.String
+		wantErr bool
+	}{
+		{"valid", types.StringValue("TLS_1_2"), false},
+		{"default", types.StringValue("DEFAULT"), false},
+		{"invalid", types.StringValue("TLSv1.2"), true},
+		{"prefixed", types.StringValue("TLS_PROTOCOL_ENUM_TLS_1_2"), true},
+		{"null", types.StringNull(), false},
+		{"unknown", types.StringUnknown(), false},
+	}
+
+	v := newEnumValidator(crypto.TLSProtocol_Enum_value)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := validator.StringResponse{}
+			v.ValidateString(context.Background(), validator.StringRequest{Path: path.Root("test"), ConfigValue: tc.value}, &resp)
+			assert.Equal(t, tc.wantErr, resp.Diagnostics.HasError())
+		})
+	}
+
+	assert.Equal(t, "value must be one of: DEFAULT, TLS_1_0, TLS_1_1, TLS_1_2, TLS_1_3", v.Description(context.Background()))
+}
+
+func TestIPAddressesValidator(t *testing.T) {
+	testCases := []struct {
+		name    string
+		value   types.Set
+		wantErr bool
+	}{
+		{"addresses", types.SetValueMust(types.StringType, []attr.Value{types.StringValue("10.0.0.1"), types.StringValue("2001:db8::1")}), false},
+		{"cidrs", types.SetValueMust(types.StringType, []attr.Value{types.StringValue("10.0.0.1/24"), types.StringValue("2001:db8::/32")}), false},
+		{"invalid address", types.SetValueMust(types.StringType, []attr.Value{types.StringValue("10.0.0.256")}), true},
+		{"invalid cidr", types.SetValueMust(types.StringType, []attr.Value{types.StringValue("10.0.0.0/33")}), true},
+		{"hostname", types.SetValueMust(types.StringType, []attr.Value{types.StringValue("example.com")}), true},
+		{"unknown element", types.SetValueMust(types.StringType, []attr.Value{types.StringUnknown()}), false},
+		{"null", types.SetNull(types.StringType), false},
+		{"unknown", types.SetUnknown(types.StringType), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := validator.SetResponse{}
+			ipAddressesValidator{}.ValidateSet(context.Background(), validator.SetRequest{Path: path.Root("test"), ConfigValue: tc.value}, &resp)
+			assert.Equal(t, tc.wantErr, resp.Diagnostics.HasError())
+		})
+	}
+}
+
+func TestNormalizeIPAddresses(t *testing.T) {
+	testCases := []struct {
+		name      string
+		addresses []string
+		want      []string
+		wantErr   bool
+	}{
+		{"empty", []string{}, []string{}, false},
+		{"masked", []string{"10.0.0.1/24"}, []string{"10.0.0.0/24"}, false},
+		{"single address cidr", []string{"10.0.0.1/32", "2001:db8::1/128"}, []string{"10.0.0.1", "2001:db8::1"}, false},
+		{"ipv6", []string{"2001:DB8:0:0::1", "2001:db8::ff/64"}, []string{"2001:db8::/64", "2001:db8::1"}, false},
+		{"duplicates", []string{"10.0.0.1/24", "10.0.0.0/24", "10.0.0.2/24"}, []string{"10.0.0.0/24"}, false},
+		{"sorted", []string{"192.168.0.1", "10.0.0.1"}, []string{"10.0.0.1", "192.168.0.1"}, false},
+		{"invalid", []string{"10.0.0.1", "invalid"}, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := normalizeIPAddresses(tc.addresses)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// providerClient is the client shared with the resources and data sources,
+// along with the provider settings changing their behavior.
+type providerClient struct {
+	assetsv1.Client
+
+	// skipVersionCheck disables the optimistic concurrency of updates
+	skipVersionCheck bool
+
+	// updateMode is either updateModePatch or updateModeReplace
+	updateMode string
+}
+
+// setPriorVersion sets the version of the object to update to the
+// metadata.version of the prior state, so that the update is rejected if the
+// object was changed since it was last read. The version is cleared when the
+// version check is disabled.
+func setPriorVersion(ctx context.Context, client assetsv1.Client, state tfsdk.State, meta *metav1.ObjectMeta) diag.Diagnostics {
+	if meta == nil {
+		return nil
+	}
+	if c, ok := client.(*providerClient); ok && c.skipVersionCheck {
+		meta.Version = 0
+		return nil
+	}
+
+	var version types.Int64
+	diags := state.GetAttribute(ctx, path.Root("metadata").AtName("version"), &version)
+	if diags.HasError() {
+		return diags
+	}
+	meta.Version = version.ValueInt64()
+	return nil
+}
+
+// isConflict returns true if err is the gRPC error of an update rejected
+// because the object version changed, which can only happen when a version
+// was sent. The API reports it as Aborted, or as FailedPrecondition with a
+// version specific detail for the services checking the version as a
+// precondition. Other precondition failures are not conflicts.
+func isConflict(err error, versionSent bool) bool {
+	if !versionSent {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Aborted:
+		return true
+	case codes.FailedPrecondition:
+		return isVersionPrecondition(status.Convert(err))
+	}
+	return false
+}
+
+// isVersionPrecondition returns true if the details of st report a failed
+// precondition on the object version.
+func isVersionPrecondition(st *status.Status) bool {
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if strings.Contains(strings.ToUpper(d.GetReason()), "VERSION") {
+				return true
+			}
+		case *errdetails.PreconditionFailure:
+			for _, violation := range d.GetViolations() {
+				if violation.GetSubject() == "metadata.version" || strings.Contains(strings.ToUpper(violation.GetType()), "VERSION") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// conflictDiagnostic reports an update rejected because the object was changed
+// since it was last read.
+func conflictDiagnostic(kind string, meta *metav1.ObjectMeta) diag.Diagnostic {
+	return diag.NewErrorDiagnostic("Resource Conflict", fmt.Sprintf(
+		"The %s %s/%s was changed outside of Terraform since it was last read. "+
+			"Refresh the state, e.g. with terraform apply -refresh-only, and review the changes before applying again. "+
+			"Set the provider skip_version_check attribute to overwrite such changes.",
+		kind, meta.GetNamespace(), meta.GetName()))
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// testVersionPreconditionError returns the FailedPrecondition error of an
+// update of another version of an object.
+func testVersionPreconditionError(t *testing.T) error {
+	st, err := status.New(codes.FailedPrecondition, "version mismatch").WithDetails(&errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{{Type: "VERSION", Subject: "metadata.version"}},
+	})
+	require.NoError(t, err)
+	return st.Err()
+}
+
+func TestIsConflict(t *testing.T) {
+	reason, err := status.New(codes.FailedPrecondition, "version mismatch").WithDetails(&errdetails.ErrorInfo{Reason: "VERSION_MISMATCH"})
+	require.NoError(t, err)
+
+	assert.True(t, isConflict(status.Error(codes.Aborted, "version conflict"), true))
+	assert.True(t, isConflict(testVersionPreconditionError(t), true))
+	assert.True(t, isConflict(reason.Err(), true))
+	assert.False(t, isConflict(status.Error(codes.FailedPrecondition, "backend not ready"), true))
+	assert.False(t, isConflict(status.Error(codes.Aborted, "version conflict"), false))
+	assert.False(t, isConflict(testVersionPreconditionError(t), false))
+	assert.False(t, isConflict(status.Error(codes.NotFound, "not found"), true))
+	assert.False(t, isConflict(nil, true))
+}
+
+func TestAssetResourceUpdateConflict(t *testing.T) {
+	ctx := context.Background()
+
+	// the API reports version conflicts with either error
+	for name, conflictErr := range map[string]error{
+		"Aborted":            status.Error(codes.Aborted, "version conflict"),
+		"FailedPrecondition": testVersionPreconditionError(t),
+	} {
+		conflictErr := conflictErr
+		t.Run(name, func(t *testing.T) {
+			asset := assetsv1.NewAsset("tf-acc-test")
+			asset.Metadata.Namespace = "tf-acc-tests"
+			asset.Metadata.Version = 2
+
+			// the asset was changed since it was last read
+			changed := proto.Clone(asset).(*assetsv1.Asset)
+			changed.Metadata.Version = 3
+			client := &providerClient{Client: &fakeClient{asset: &fakeAssetSvcClient{asset: changed, conflictErr: conflictErr}}}
+			r := &AssetResource{client: client}
+
+			update := func() fwresource.UpdateResponse {
+				state := testAssetState(t, asset)
+				resp := fwresource.UpdateResponse{State: state}
+				r.Update(ctx, fwresource.UpdateRequest{
+					State: state,
+					Plan:  tfsdk.Plan{Schema: state.Schema, Raw: state.Raw},
+				}, &resp)
+				return resp
+			}
+
+			// the update is rejected
+			resp := update()
+			require.True(t, resp.Diagnostics.HasError())
+			assert.Equal(t, "Resource Conflict", resp.Diagnostics.Errors()[0].Summary())
+			assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "tf-acc-tests/tf-acc-test")
+
+			// the update overwrites the changes when the version check is skipped
+			client.skipVersionCheck = true
+			resp = update()
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.Equal(t, int64(4), client.Client.(*fakeClient).asset.asset.GetMetadata().GetVersion())
+
+			// the update of the last read version succeeds
+			client.skipVersionCheck = false
+			asset.Metadata.Version = 4
+			resp = update()
+			require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+			assert.Equal(t, int64(5), client.Client.(*fakeClient).asset.asset.GetMetadata().GetVersion())
+		})
+	}
+
+	// other precondition failures are reported as is, the version check being
+	// skipped or not
+	for _, skipVersionCheck := range []bool{true, false} {
+		asset := assetsv1.NewAsset("tf-acc-test")
+		asset.Metadata.Namespace = "tf-acc-tests"
+		asset.Metadata.Version = 2
+		updateErr := status.Error(codes.FailedPrecondition, "backend not ready")
+		client := &providerClient{
+			Client:           &fakeClient{asset: &fakeAssetSvcClient{asset: asset, updateErr: updateErr}},
+			skipVersionCheck: skipVersionCheck,
+		}
+		r := &AssetResource{client: client}
+
+		state := testAssetState(t, asset)
+		resp := fwresource.UpdateResponse{State: state}
+		r.Update(ctx, fwresource.UpdateRequest{
+			State: state,
+			Plan:  tfsdk.Plan{Schema: state.Schema, Raw: state.Raw},
+		}, &resp)
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "Client Error", resp.Diagnostics.Errors()[0].Summary())
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), updateErr.Error())
+	}
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+)
+
+const (
+	// defaultAssetTimeout is the default time to wait for an asset to be ready
+	// or deleted.
+	defaultAssetTimeout = 20 * time.Minute
+)
+
+// waitPollInterval is the interval between two reads of an object waited for
+// when it cannot be watched.
+var waitPollInterval = 10 * time.Second
+
+// assetWaitForModel is the wait_for block of the asset resource, the states
+// the asset must reach before its creation or update completes.
+type assetWaitForModel struct {
+	RunningState types.String `tfsdk:"running_state"`
+	Backend      types.String `tfsdk:"backend"`
+	Dns          types.String `tfsdk:"dns"`
+}
+
+// ready returns true if the state of the asset matches the expected states.
+func (w *assetWaitForModel) ready(asset *assetsv1.Asset) bool {
+	if w == nil {
+		return true
+	}
+	state := asset.GetStatus().GetState()
+	return enumMatches(w.RunningState, assetsv1.RunningState_Enum_value, int32(state.GetRunningstate())) &&
+		enumMatches(w.Backend, assetsv1.BackendState_Enum_value, int32(state.GetBackend())) &&
+		enumMatches(w.Dns, assetsv1.DnsState_Enum_value, int32(state.GetDns()))
+}
+
+// enumMatches returns true if the expected enum name is not set or is the
+// name of value.
+func enumMatches(expected types.String, values map[string]int32, value int32) bool {
+	if expected.IsNull() || expected.IsUnknown() {
+		return true
+	}
+	v, ok := values[expected.ValueString()]
+	return ok && v == value
+}
+
+// waitForAsset waits until the asset is in the states of waitFor, or the
+// context is done.
+func waitForAsset(ctx context.Context, svc assetsv1.AssetSvcClient, meta *metav1.ObjectMeta, waitFor *assetWaitForModel) (*assetsv1.Asset, error) {
+	var last *assetsv1.Asset
+	err := watchAsset(ctx, svc, meta, func(asset *assetsv1.Asset) (bool, error) {
+		if asset == nil {
+			return false, fmt.Errorf("asset %s/%s was deleted", meta.GetNamespace(), meta.GetName())
+		}
+		last = asset
+		return waitFor.ready(asset), nil
+	})
+	if errors.Is(err, context.DeadlineExceeded) && last != nil {
+		state := last.GetStatus().GetState()
+		return last, fmt.Errorf("timeout while waiting for asset %s/%s, last state: running_state %s, backend %s, dns %s",
+			meta.GetNamespace(), meta.GetName(), state.GetRunningstate(), state.GetBackend(), state.GetDns())
+	}
+	return last, err
+}
+
+// waitForAssetDeletion waits until the asset is deleted, or the context is
+// done.
+func waitForAssetDeletion(ctx context.Context, svc assetsv1.AssetSvcClient, meta *metav1.ObjectMeta) error {
+	err := watchAsset(ctx, svc, meta, func(asset *assetsv1.Asset) (bool, error) {
+		return asset == nil, nil
+	})
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("timeout while waiting for asset %s/%s to be deleted", meta.GetNamespace(), meta.GetName())
+	}
+	return err
+}
+
+// watchAsset calls done with the asset each time it changes, or with nil once
+// it is deleted, until done returns true or an error, or the context is done.
+//
+// The changes are watched with the Watch method of the asset service, the
+// asset is read again on each event. It is polled every waitPollInterval when
+// it cannot be watched. The stream is not retried on transient errors, the
+// retry interceptor of the provider only handling unary calls, polling takes
+// over instead.
+func watchAsset(ctx context.Context, svc assetsv1.AssetSvcClient, meta *metav1.ObjectMeta, done func(*assetsv1.Asset) (bool, error)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// the watch is opened before the first read so that no change is missed
+	stream, err := svc.Watch(ctx, &metav1.WatchOptions{
+		Namespace: meta.GetNamespace(),
+		Name:      meta.GetName(),
+	})
+	if err != nil {
+		tflog.Debug(ctx, "Unable to watch asset, polling it", map[string]interface{}{"error": err.Error()})
+		stream = nil
+	}
+
+	for {
+		asset, err := svc.Get(ctx, &metav1.GetOptions{
+			Namespace: meta.GetNamespace(),
+			Name:      meta.GetName(),
+		})
+		if isNotFound(err) {
+			asset, err = nil, nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		if ok, err := done(asset); ok || err != nil {
+			return err
+		}
+
+		if stream != nil {
+			event, err := stream.Recv()
+			switch {
+			case err == nil && event.GetType() == metav1.WatchEvent_DELETE:
+				if ok, err := done(nil); ok || err != nil {
+					return err
+				}
+				continue
+			case err == nil:
+				continue
+			case ctx.Err() != nil:
+				return ctx.Err()
+			case !errors.Is(err, io.EOF):
+				tflog.Debug(ctx, "Asset watch failed, polling it", map[string]interface{}{"error": err.Error()})
+			}
+			stream = nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(waitPollInterval):
+		}
+	}
+}
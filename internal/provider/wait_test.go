@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeWatchAssetSvcClient is an assetsv1.AssetSvcClient returning the
+// successive states of an asset, nil once it is deleted.
+type fakeWatchAssetSvcClient struct {
+	assetsv1.AssetSvcClient
+	assets   []*assetsv1.Asset
+	gets     int
+	events   []*metav1.WatchEvent
+	watchErr error
+}
+
+func (c *fakeWatchAssetSvcClient) Get(ctx context.Context, in *metav1.GetOptions, opts ...grpc.CallOption) (*assetsv1.Asset, error) {
+	asset := c.assets[len(c.assets)-1]
+	if c.gets < len(c.assets) {
+		asset = c.assets[c.gets]
+	}
+	c.gets++
+	if asset == nil {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	return asset, nil
+}
+
+func (c *fakeWatchAssetSvcClient) Delete(ctx context.Context, in *metav1.DeleteOptions, opts ...grpc.CallOption) (*assetsv1.Asset, error) {
+	return c.Get(ctx, &metav1.GetOptions{Namespace: in.GetNamespace(), Name: in.GetName()})
+}
+
+func (c *fakeWatchAssetSvcClient) Watch(ctx context.Context, in *metav1.WatchOptions, opts ...grpc.CallOption) (assetsv1.AssetSvc_WatchClient, error) {
+	if c.watchErr != nil {
+		return nil, c.watchErr
+	}
+	return &fakeAssetWatchClient{svc: c}, nil
+}
+
+// fakeAssetWatchClient streams the events of a fakeWatchAssetSvcClient.
+type fakeAssetWatchClient struct {
+	grpc.ClientStream
+	svc *fakeWatchAssetSvcClient
+}
+
+func (c *fakeAssetWatchClient) Recv() (*metav1.WatchEvent, error) {
+	if len(c.svc.events) == 0 {
+		return nil, io.EOF
+	}
+	event := c.svc.events[0]
+	c.svc.events = c.svc.events[1:]
+	return event, nil
+}
+
+// fakeWatchClient is an assetsv1.Client only implementing the asset service,
+// with watch support.
+type fakeWatchClient struct {
+	assetsv1.Client
+	asset *fakeWatchAssetSvcClient
+}
+
+func (c *fakeWatchClient) Asset() assetsv1.AssetSvcClient {
+	return c.asset
+}
+
+func testWaitAsset(runningState assetsv1.RunningState_Enum, backend assetsv1.BackendState_Enum) *assetsv1.Asset {
+	asset := assetsv1.NewAsset("tf-acc-test")
+	asset.Metadata.Namespace = "tf-acc-tests"
+	asset.Status = &assetsv1.AssetStatus{State: &assetsv1.AssetState{Runningstate: runningState, Backend: backend}}
+	return asset
+}
+
+func TestAssetWaitForReady(t *testing.T) {
+	asset := testWaitAsset(assetsv1.RunningState_ALIVE, assetsv1.BackendState_TIMEOUT)
+
+	var waitFor *assetWaitForModel
+	assert.True(t, waitFor.ready(asset))
+
+	waitFor = &assetWaitForModel{RunningState: types.StringValue("ALIVE"), Backend: types.StringNull(), Dns: types.StringNull()}
+	assert.True(t, waitFor.ready(asset))
+
+	waitFor.RunningState = types.StringValue("RUNNING_STATE_ALIVE")
+	assert.True(t, waitFor.ready(asset))
+
+	waitFor.Backend = types.StringValue("OK")
+	assert.False(t, waitFor.ready(asset))
+}
+
+func TestWaitForAsset(t *testing.T) {
+	ctx := context.Background()
+	waitFor := &assetWaitForModel{RunningState: types.StringValue("ALIVE"), Backend: types.StringValue("OK"), Dns: types.StringNull()}
+
+	defer func(interval time.Duration) { waitPollInterval = interval }(waitPollInterval)
+	waitPollInterval = time.Millisecond
+
+	t.Run("watch", func(t *testing.T) {
+		svc := &fakeWatchAssetSvcClient{
+			assets: []*assetsv1.Asset{
+				testWaitAsset(assetsv1.RunningState_UNKNOWN, assetsv1.BackendState_TIMEOUT),
+				testWaitAsset(assetsv1.RunningState_ALIVE, assetsv1.BackendState_TIMEOUT),
+				testWaitAsset(assetsv1.RunningState_ALIVE, assetsv1.BackendState_OK),
+			},
+			events: []*metav1.WatchEvent{{Type: metav1.WatchEvent_UPDATE}, {Type: metav1.WatchEvent_UPDATE}},
+		}
+		asset, err := waitForAsset(ctx, svc, svc.assets[0].Metadata, waitFor)
+		require.NoError(t, err)
+		assert.Equal(t, assetsv1.BackendState_OK, asset.GetStatus().GetState().GetBackend())
+		assert.Equal(t, 3, svc.gets)
+		assert.Empty(t, svc.events)
+	})
+
+	t.Run("polling", func(t *testing.T) {
+		svc := &fakeWatchAssetSvcClient{
+			assets: []*assetsv1.Asset{
+				testWaitAsset(assetsv1.RunningState_UNKNOWN, assetsv1.BackendState_OK),
+				testWaitAsset(assetsv1.RunningState_ALIVE, assetsv1.BackendState_OK),
+			},
+			watchErr: status.Error(codes.Unimplemented, "unimplemented"),
+		}
+		asset, err := waitForAsset(ctx, svc, svc.assets[0].Metadata, waitFor)
+		require.NoError(t, err)
+		assert.Equal(t, assetsv1.RunningState_ALIVE, asset.GetStatus().GetState().GetRunningstate())
+		assert.Equal(t, 2, svc.gets)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		svc := &fakeWatchAssetSvcClient{
+			assets: []*assetsv1.Asset{testWaitAsset(assetsv1.RunningState_UNKNOWN, assetsv1.BackendState_OK)},
+		}
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		asset, err := waitForAsset(ctx, svc, svc.assets[0].Metadata, waitFor)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "last state: running_state UNKNOWN, backend OK")
+		assert.NotNil(t, asset)
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		svc := &fakeWatchAssetSvcClient{
+			assets: []*assetsv1.Asset{testWaitAsset(assetsv1.RunningState_UNKNOWN, assetsv1.BackendState_OK)},
+			events: []*metav1.WatchEvent{{Type: metav1.WatchEvent_DELETE}},
+		}
+		_, err := waitForAsset(ctx, svc, svc.assets[0].Metadata, waitFor)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "was deleted")
+	})
+}
+
+func TestWaitForAssetDeletion(t *testing.T) {
+	ctx := context.Background()
+	alive := testWaitAsset(assetsv1.RunningState_ALIVE, assetsv1.BackendState_OK)
+
+	defer func(interval time.Duration) { waitPollInterval = interval }(waitPollInterval)
+	waitPollInterval = time.Millisecond
+
+	t.Run("delete event", func(t *testing.T) {
+		svc := &fakeWatchAssetSvcClient{
+			assets: []*assetsv1.Asset{alive, alive},
+			events: []*metav1.WatchEvent{{Type: metav1.WatchEvent_UPDATE}, {Type: metav1.WatchEvent_DELETE}},
+		}
+		require.NoError(t, waitForAssetDeletion(ctx, svc, alive.Metadata))
+		assert.Equal(t, 2, svc.gets)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		svc := &fakeWatchAssetSvcClient{
+			assets:   []*assetsv1.Asset{alive, alive, nil},
+			watchErr: status.Error(codes.Unimplemented, "unimplemented"),
+		}
+		require.NoError(t, waitForAssetDeletion(ctx, svc, alive.Metadata))
+		assert.Equal(t, 3, svc.gets)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		svc := &fakeWatchAssetSvcClient{assets: []*assetsv1.Asset{alive}}
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		err := waitForAssetDeletion(ctx, svc, alive.Metadata)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "to be deleted")
+	})
+}
+
+func TestAssetResourceDeleteWait(t *testing.T) {
+	ctx := context.Background()
+
+	defer func(interval time.Duration) { waitPollInterval = interval }(waitPollInterval)
+	waitPollInterval = time.Millisecond
+
+	// the asset is torn down after the Delete call returns
+	asset := testWaitAsset(assetsv1.RunningState_ALIVE, assetsv1.BackendState_OK)
+	svc := &fakeWatchAssetSvcClient{assets: []*assetsv1.Asset{asset, asset, asset, nil}}
+	r := &AssetResource{client: &fakeWatchClient{asset: svc}}
+
+	state := testAssetState(t, asset)
+	resp := fwresource.DeleteResponse{State: state}
+	r.Delete(ctx, fwresource.DeleteRequest{State: state}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	assert.Equal(t, 4, svc.gets)
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccWorkflowDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccWorkflowDataSourceConfig("tf-acc-test", "tf-acc-tests"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ubika_workflow.test", "id", "tf-acc-tests/tf-acc-test"),
+					resource.TestCheckResourceAttrPair("data.ubika_workflow.test", "spec.entrypoint", "ubika_workflow.test", "spec.entrypoint"),
+				),
+			},
+		},
+	})
+}
+
+func testAccWorkflowDataSourceConfig(name string, namespace string) string {
+	return testAccWorkflowResourceConfig(name, namespace, "main") + fmt.Sprintf(`
+data "ubika_workflow" "test" {
+  metadata = {
+    name = ubika_workflow.test.metadata.name
+    namespace = %[1]q
+  }
+}
+`, namespace)
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccWorkflowResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccWorkflowResourceConfig("tf-acc-test", "tf-acc-tests", "main"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ubika_workflow.test", "metadata.name", "tf-acc-test"),
+					resource.TestCheckResourceAttr("ubika_workflow.test", "spec.entrypoint", "main"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "ubika_workflow.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.namespace", "defaulted"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccWorkflowResourceConfig("tf-acc-test", "tf-acc-tests", "run"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ubika_workflow.test", "metadata.namespace", "tf-acc-tests"),
+					resource.TestCheckResourceAttr("ubika_workflow.test", "spec.entrypoint", "run"),
+				),
+			},
+			// // Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccWorkflowResourceConfig(name string, namespace string, entrypoint string) string {
+	return fmt.Sprintf(`
+resource "ubika_workflow" "test" {
+  metadata = {
+    name = %[1]q
+    namespace = %[2]q
+  }
+  spec = {
+	source = <<EOT
+function main() end
+function run() end
+EOT
+	entrypoint = %[3]q
+  }
+}
+`, name, namespace, entrypoint)
+}
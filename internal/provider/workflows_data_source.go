@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	assetsv1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/assets.ubika.io/v1beta"
+	metav1 "github.com/ubikasec/terraform-provider-ubika/internal/apis/meta/v1beta"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &WorkflowsDataSource{}
+
+func NewWorkflowsDataSource() datasource.DataSource {
+	return &WorkflowsDataSource{}
+}
+
+// WorkflowsDataSource defines the data source implementation.
+type WorkflowsDataSource struct {
+	client assetsv1.Client
+}
+
+// WorkflowsDataSourceModel describes the data source data model.
+type WorkflowsDataSourceModel struct {
+	Namespace types.String                     `tfsdk:"namespace"`
+	Items     []assetsv1.WorkflowResourceModel `tfsdk:"items"`
+}
+
+func (d *WorkflowsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflows"
+}
+
+func (d *WorkflowsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Workflows data source, lists the workflows of a namespace",
+
+		Attributes: listDataSourceAttributes(ctx, NewWorkflowResource()),
+	}
+}
+
+func (d *WorkflowsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(assetsv1.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *assetsv1.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *WorkflowsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Info(ctx, "Reading Workflows")
+
+	// Read Terraform configuration data into the model
+	var state WorkflowsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workflows, err := d.client.Workflow().List(ctx, &metav1.ListOptions{
+		Namespace: state.Namespace.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list workflows, got error: %s", err))
+		return
+	}
+
+	// generate state from protobuf resources
+	state.Items = make([]assetsv1.WorkflowResourceModel, 0, len(workflows.GetItems()))
+	for _, workflow := range workflows.GetItems() {
+		var item assetsv1.WorkflowResourceModel
+		if _, err := item.FromProto(workflow); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get state from workflow %s/%s, got error: %s", workflow.GetMetadata().GetNamespace(), workflow.GetMetadata().GetName(), err))
+			return
+		}
+		state.Items = append(state.Items, item)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}